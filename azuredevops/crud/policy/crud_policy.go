@@ -0,0 +1,469 @@
+package crudpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// the clients are passed to the flatten and expand funcs, as some policy settings reference other
+// AzDO entities by an ID that differs from the ID exposed by the provider
+type flatFunc func(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error
+type expandFunc func(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error)
+
+const (
+	// SchemaSettings is the key of the block holding the policy type specific settings
+	SchemaSettings         = "settings"
+	schemaScope            = "scope"
+	schemaFilenamePatterns = "filename_patterns"
+)
+
+// the scope of a repository policy as it is stored in the policy settings
+type repositoryPolicyScope struct {
+	RepositoryID *string `json:"repositoryId"`
+}
+
+// the scope of a branch policy as it is stored in the policy settings
+type branchPolicyScope struct {
+	RepositoryID *string `json:"repositoryId"`
+	RefName      *string `json:"refName,omitempty"`
+	MatchKind    *string `json:"matchKind,omitempty"`
+}
+
+// GenBasePolicyResource creates a Resource with the common parts
+// that all policies require. The policy type specific settings have to be added by the caller.
+func GenBasePolicyResource(f flatFunc, e expandFunc) *schema.Resource {
+	return genPolicyResource(f, e, genBaseSchema())
+}
+
+// GenBaseBranchPolicyResource creates a Resource with the common parts
+// that all branch policies require.
+func GenBaseBranchPolicyResource(f flatFunc, e expandFunc) *schema.Resource {
+	return genPolicyResource(f, e, genBaseBranchPolicySchema())
+}
+
+// GenBaseRepositoryPolicyResource creates a Resource with the common parts
+// that all repository policies require.
+func GenBaseRepositoryPolicyResource(f flatFunc, e expandFunc) *schema.Resource {
+	return genPolicyResource(f, e, genBaseRepositoryPolicySchema())
+}
+
+func genPolicyResource(f flatFunc, e expandFunc, s map[string]*schema.Schema) *schema.Resource {
+	return &schema.Resource{
+		Create: genPolicyCreateFunc(f, e),
+		Read:   genPolicyReadFunc(f),
+		Update: genPolicyUpdateFunc(f, e),
+		Delete: genPolicyDeleteFunc(),
+		Importer: &schema.ResourceImporter{
+			State: importPolicy,
+		},
+		Schema: s,
+	}
+}
+
+func genBaseSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.UUID,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"blocking": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+	}
+}
+
+func genBaseBranchPolicySchema() map[string]*schema.Schema {
+	s := genBaseSchema()
+	s[SchemaSettings] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				schemaScope: {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"repository_id": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validate.UUIDOrEmpty,
+							},
+							"repository_ref": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"match_type": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								Default:          "Exact",
+								ValidateFunc:     validation.StringInSlice([]string{"Exact", "Prefix"}, true),
+								DiffSuppressFunc: suppress.CaseDifference,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return s
+}
+
+func genBaseRepositoryPolicySchema() map[string]*schema.Schema {
+	s := genBaseSchema()
+	s["repository_ids"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validate.UUID,
+		},
+	}
+	return s
+}
+
+// GetSettingsSchema returns the schema of the settings block, so that policy type specific settings can be added to it
+func GetSettingsSchema(r *schema.Resource) map[string]*schema.Schema {
+	return r.Schema[SchemaSettings].Elem.(*schema.Resource).Schema
+}
+
+// DoBaseExpansion performs the expansion for the 'base' attributes that are defined in the schema, above.
+// The settings of the returned policy configuration contain the scope of the policy and can be extended
+// with the policy type specific settings.
+func DoBaseExpansion(d *schema.ResourceData, policyTypeID uuid.UUID) (*policy.PolicyConfiguration, *string, map[string]interface{}) {
+	scopes := expandBranchPolicyScopes(d.Get(SchemaSettings + ".0." + schemaScope).([]interface{}))
+	return expandBasePolicy(d, policyTypeID, scopes)
+}
+
+// DoBaseRepositoryPolicyExpansion performs the expansion for the 'base' attributes of a repository policy.
+// The settings of the returned policy configuration contain the scope of the policy and can be extended
+// with the policy type specific settings.
+func DoBaseRepositoryPolicyExpansion(d *schema.ResourceData, policyTypeID uuid.UUID) (*policy.PolicyConfiguration, *string, map[string]interface{}) {
+	scopes := expandRepositoryPolicyScopes(d.Get("repository_ids").([]interface{}))
+	return expandBasePolicy(d, policyTypeID, scopes)
+}
+
+// DoBasePolicyExpansion performs the expansion for the 'base' attributes that all policies share.
+// The settings of the returned policy configuration are not set.
+func DoBasePolicyExpansion(d *schema.ResourceData, policyTypeID uuid.UUID) (*policy.PolicyConfiguration, *string) {
+	// an "error" is OK here as it is expected in the case that the ID is not set in the resource data
+	var policyID *int
+	parsedID, err := strconv.Atoi(d.Id())
+	if err == nil {
+		policyID = &parsedID
+	}
+
+	projectID := converter.String(d.Get("project_id").(string))
+	policyConfig := &policy.PolicyConfiguration{
+		Id:         policyID,
+		IsEnabled:  converter.Bool(d.Get("enabled").(bool)),
+		IsBlocking: converter.Bool(d.Get("blocking").(bool)),
+		Type: &policy.PolicyTypeRef{
+			Id: &policyTypeID,
+		},
+	}
+
+	return policyConfig, projectID
+}
+
+func expandBasePolicy(d *schema.ResourceData, policyTypeID uuid.UUID, scopes interface{}) (*policy.PolicyConfiguration, *string, map[string]interface{}) {
+	policyConfig, projectID := DoBasePolicyExpansion(d, policyTypeID)
+	settings := map[string]interface{}{
+		"scope": scopes,
+	}
+	policyConfig.Settings = settings
+
+	return policyConfig, projectID, settings
+}
+
+func expandRepositoryPolicyScopes(repoIDs []interface{}) []repositoryPolicyScope {
+	// a scope without a repository applies to all repositories of the project
+	if len(repoIDs) == 0 {
+		return []repositoryPolicyScope{{RepositoryID: nil}}
+	}
+
+	results := make([]repositoryPolicyScope, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		results = append(results, repositoryPolicyScope{RepositoryID: converter.String(repoID.(string))})
+	}
+	return results
+}
+
+func expandBranchPolicyScopes(scopes []interface{}) []branchPolicyScope {
+	results := make([]branchPolicyScope, 0, len(scopes))
+	for _, raw := range scopes {
+		scope := raw.(map[string]interface{})
+		result := branchPolicyScope{
+			MatchKind: converter.String(scope["match_type"].(string)),
+		}
+
+		// a scope without a repository applies to all repositories of the project
+		if repoID := scope["repository_id"].(string); repoID != "" {
+			result.RepositoryID = converter.String(repoID)
+		}
+		if refName := scope["repository_ref"].(string); refName != "" {
+			result.RefName = converter.String(refName)
+		}
+
+		results = append(results, result)
+	}
+	return results
+}
+
+// DoBaseFlattening performs the flattening for the 'base' attributes that are defined in the schema, above.
+// The returned settings block contains the scope of the policy and can be extended with the policy type
+// specific settings before it is stored in the resource data.
+func DoBaseFlattening(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration, projectID *string) (map[string]interface{}, error) {
+	DoBasePolicyFlattening(d, policyConfig, projectID)
+
+	var settings struct {
+		Scope []branchPolicyScope `json:"scope"`
+	}
+	if err := DecodeSettings(policyConfig, &settings); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		schemaScope: flattenBranchPolicyScopes(settings.Scope),
+	}, nil
+}
+
+// DoBaseRepositoryPolicyFlattening performs the flattening for the 'base' attributes of a repository policy
+func DoBaseRepositoryPolicyFlattening(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	DoBasePolicyFlattening(d, policyConfig, projectID)
+
+	var settings struct {
+		Scope []repositoryPolicyScope `json:"scope"`
+	}
+	if err := DecodeSettings(policyConfig, &settings); err != nil {
+		return err
+	}
+
+	repoIDs := make([]interface{}, 0, len(settings.Scope))
+	for _, scope := range settings.Scope {
+		if scope.RepositoryID != nil {
+			repoIDs = append(repoIDs, *scope.RepositoryID)
+		}
+	}
+	return d.Set("repository_ids", repoIDs)
+}
+
+// DoBasePolicyFlattening performs the flattening for the 'base' attributes that all policies share
+func DoBasePolicyFlattening(d *schema.ResourceData, policyConfig *policy.PolicyConfiguration, projectID *string) {
+	d.SetId(strconv.Itoa(*policyConfig.Id))
+	d.Set("project_id", converter.ToString(projectID, ""))
+	d.Set("enabled", converter.ToBool(policyConfig.IsEnabled, true))
+	d.Set("blocking", converter.ToBool(policyConfig.IsBlocking, true))
+}
+
+func flattenBranchPolicyScopes(scopes []branchPolicyScope) []interface{} {
+	results := make([]interface{}, 0, len(scopes))
+	for _, scope := range scopes {
+		results = append(results, map[string]interface{}{
+			"repository_id":  converter.ToString(scope.RepositoryID, ""),
+			"repository_ref": converter.ToString(scope.RefName, ""),
+			"match_type":     converter.ToString(scope.MatchKind, "Exact"),
+		})
+	}
+	return results
+}
+
+// ExpandFilenamePatterns adds the configured path filter of a branch policy to the policy settings.
+// The policy applies to all files of the pull request if no path filter is configured.
+func ExpandFilenamePatterns(d *schema.ResourceData, settings map[string]interface{}) {
+	patterns := d.Get(SchemaSettings + ".0." + schemaFilenamePatterns).([]interface{})
+	if len(patterns) == 0 {
+		return
+	}
+
+	filenamePatterns := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		filenamePatterns[i] = pattern.(string)
+	}
+	settings["filenamePatterns"] = filenamePatterns
+}
+
+// FlattenFilenamePatterns adds the path filter of a branch policy to the given settings block
+func FlattenFilenamePatterns(policyConfig *policy.PolicyConfiguration, settings map[string]interface{}) error {
+	var policySettings struct {
+		FilenamePatterns []string `json:"filenamePatterns"`
+	}
+	if err := DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	settings[schemaFilenamePatterns] = policySettings.FilenamePatterns
+	return nil
+}
+
+// DecodeSettings converts the untyped settings of a policy configuration into the given structure
+func DecodeSettings(policyConfig *policy.PolicyConfiguration, v interface{}) error {
+	if policyConfig.Settings == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(policyConfig.Settings)
+	if err != nil {
+		return fmt.Errorf("Error reading the settings of policy %d: %+v", converter.ToInt(policyConfig.Id, 0), err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("Error reading the settings of policy %d: %+v", converter.ToInt(policyConfig.Id, 0), err)
+	}
+	return nil
+}
+
+// GetIdentityStorageKey resolves the storage key of a user or group. Policies reference users and groups
+// by their storage key, while the provider exposes their descriptors.
+func GetIdentityStorageKey(clients *config.AggregatedClient, descriptor string) (string, error) {
+	storageKey, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{
+		SubjectDescriptor: converter.String(descriptor),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error resolving the identity with descriptor %s: %+v", descriptor, err)
+	}
+	return storageKey.Value.String(), nil
+}
+
+// GetIdentityDescriptor resolves the descriptor of a user or group referenced by a policy
+func GetIdentityDescriptor(clients *config.AggregatedClient, storageKey string) (string, error) {
+	key, err := uuid.Parse(storageKey)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing the ID of identity %s: %+v", storageKey, err)
+	}
+
+	descriptor, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{
+		StorageKey: &key,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error resolving the descriptor of identity %s: %+v", storageKey, err)
+	}
+	return converter.ToString(descriptor.Value, ""), nil
+}
+
+func genPolicyCreateFunc(flatFunc flatFunc, expandFunc expandFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*config.AggregatedClient)
+		policyConfig, projectID, err := expandFunc(d, clients)
+		if err != nil {
+			return fmt.Errorf("Error converting terraform data model to AzDO policy configuration: %+v", err)
+		}
+
+		createdPolicy, err := clients.PolicyClient.CreatePolicyConfiguration(clients.Ctx, policy.CreatePolicyConfigurationArgs{
+			Configuration: policyConfig,
+			Project:       projectID,
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating policy in Azure DevOps: %+v", err)
+		}
+
+		return flatFunc(d, clients, createdPolicy, projectID)
+	}
+}
+
+func genPolicyReadFunc(flatFunc flatFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*config.AggregatedClient)
+		projectID, policyID, err := tfhelper.ParseProjectIDAndResourceID(d)
+		if err != nil {
+			return fmt.Errorf("Error parsing the policy ID from the Terraform resource data: %v", err)
+		}
+
+		policyConfig, err := clients.PolicyClient.GetPolicyConfiguration(clients.Ctx, policy.GetPolicyConfigurationArgs{
+			Project:         converter.String(projectID),
+			ConfigurationId: &policyID,
+		})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("Error looking up policy given ID (%d) and project ID (%s): %v", policyID, projectID, err)
+		}
+
+		if converter.ToBool(policyConfig.IsDeleted, false) {
+			d.SetId("")
+			return nil
+		}
+
+		return flatFunc(d, clients, policyConfig, &projectID)
+	}
+}
+
+func genPolicyUpdateFunc(flatFunc flatFunc, expandFunc expandFunc) schema.UpdateFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*config.AggregatedClient)
+		policyConfig, projectID, err := expandFunc(d, clients)
+		if err != nil {
+			return fmt.Errorf("Error converting terraform data model to AzDO policy configuration: %+v", err)
+		}
+
+		updatedPolicy, err := clients.PolicyClient.UpdatePolicyConfiguration(clients.Ctx, policy.UpdatePolicyConfigurationArgs{
+			ConfigurationId: policyConfig.Id,
+			Configuration:   policyConfig,
+			Project:         projectID,
+		})
+		if err != nil {
+			return fmt.Errorf("Error updating policy in Azure DevOps: %+v", err)
+		}
+
+		return flatFunc(d, clients, updatedPolicy, projectID)
+	}
+}
+
+func genPolicyDeleteFunc() schema.DeleteFunc {
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients := m.(*config.AggregatedClient)
+		projectID, policyID, err := tfhelper.ParseProjectIDAndResourceID(d)
+		if err != nil {
+			return fmt.Errorf("Error parsing the policy ID from the Terraform resource data: %v", err)
+		}
+
+		err = clients.PolicyClient.DeletePolicyConfiguration(clients.Ctx, policy.DeletePolicyConfigurationArgs{
+			Project:         converter.String(projectID),
+			ConfigurationId: &policyID,
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting policy in Azure DevOps: %+v", err)
+		}
+
+		d.SetId("")
+		return nil
+	}
+}
+
+// policies are imported using the ID of the project and the ID of the policy: projectId/policyId
+func importPolicy(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, policyID, err := tfhelper.ParseImportedID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the policy ID from the Terraform resource data: %v", err)
+	}
+
+	d.Set("project_id", projectID)
+	d.SetId(strconv.Itoa(policyID))
+	return []*schema.ResourceData{d}, nil
+}
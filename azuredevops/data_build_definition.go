@@ -0,0 +1,148 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func dataBuildDefinition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildDefinitionRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "\\",
+				ValidateFunc: validate.FilePathOrEmpty,
+			},
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"agent_pool_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"agent_queue_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"variable_groups": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"repository": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"yml_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"repo_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"repo_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"branch_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"workspace_mapping": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"server_path": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"mapping_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"local_path": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	namePath := strings.Trim(d.Get("path").(string), "\\") + "\\" + d.Get("name").(string)
+
+	buildDefinitionID, err := getBuildDefinitionIDByPath(clients, projectID, namePath)
+	if err != nil {
+		return err
+	}
+
+	buildDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: converter.Int(buildDefinitionID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error looking up build definition %s in project %s: %+v", namePath, projectID, err)
+	}
+
+	d.SetId(strconv.Itoa(buildDefinitionID))
+	return flattenBuildDefinitionDataSource(d, buildDefinition)
+}
+
+func flattenBuildDefinitionDataSource(d *schema.ResourceData, buildDefinition *build.BuildDefinition) error {
+	d.Set("name", converter.ToString(buildDefinition.Name, ""))
+	d.Set("path", converter.ToString(buildDefinition.Path, ""))
+	d.Set("revision", converter.ToInt(buildDefinition.Revision, 0))
+	if buildDefinition.Queue != nil {
+		d.Set("agent_pool_name", converter.ToString(buildDefinition.Queue.Name, ""))
+		d.Set("agent_queue_id", converter.ToInt(buildDefinition.Queue.Id, 0))
+	}
+	if err := d.Set("variable_groups", flattenVariableGroups(buildDefinition)); err != nil {
+		return err
+	}
+
+	repository := []map[string]interface{}{}
+	if buildDefinition.Repository != nil {
+		repository = flattenRepository(buildDefinition).([]map[string]interface{})
+	}
+	return d.Set("repository", repository)
+}
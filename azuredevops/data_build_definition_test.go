@@ -0,0 +1,168 @@
+// +build all data_build_definition
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testDataBuildDefinitionProjectID = uuid.New().String()
+
+var testDataBuildDefinition = build.BuildDefinition{
+	Id:       converter.Int(100),
+	Revision: converter.Int(3),
+	Name:     converter.String("Name"),
+	Path:     converter.String(`\folder`),
+	Repository: &build.BuildRepository{
+		Id:            converter.String("repoOrg/repoName"),
+		Name:          converter.String("repoOrg/repoName"),
+		DefaultBranch: converter.String("master"),
+		Type:          converter.String("GitHub"),
+	},
+	Process: &build.YamlProcess{
+		YamlFilename: converter.String("azure-pipelines.yml"),
+	},
+	Queue: &build.AgentPoolQueue{
+		Id:   converter.Int(5),
+		Name: converter.String("BuildPoolName"),
+	},
+	VariableGroups: &[]build.VariableGroup{{Id: converter.Int(7)}},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that a build definition is looked up by its name in the configured folder
+func TestDataSourceBuildDefinition_Read_LooksUpDefinitionByPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetDefinitions(clients.Ctx, build.GetDefinitionsArgs{
+			Project: converter.String(testDataBuildDefinitionProjectID),
+			Name:    converter.String("Name"),
+			Path:    converter.String(`\folder`),
+		}).
+		Return(&build.GetDefinitionsResponseValue{
+			Value: []build.BuildDefinitionReference{{Id: testDataBuildDefinition.Id}},
+		}, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: converter.String(testDataBuildDefinitionProjectID), DefinitionId: testDataBuildDefinition.Id}).
+		Return(&testDataBuildDefinition, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataBuildDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataBuildDefinitionProjectID)
+	resourceData.Set("name", "Name")
+	resourceData.Set("path", `\folder\`)
+
+	err := dataSourceBuildDefinitionRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "100", resourceData.Id())
+	require.Equal(t, *testDataBuildDefinition.Revision, resourceData.Get("revision"))
+	require.Equal(t, "BuildPoolName", resourceData.Get("agent_pool_name"))
+	require.Equal(t, 5, resourceData.Get("agent_queue_id"))
+	require.Equal(t, "azure-pipelines.yml", resourceData.Get("repository.0.yml_path"))
+	require.Equal(t, "repoOrg/repoName", resourceData.Get("repository.0.repo_name"))
+	require.Equal(t, []interface{}{7}, resourceData.Get("variable_groups").(*schema.Set).List())
+}
+
+// verifies that definitions in the root folder are looked up in the root folder only
+func TestDataSourceBuildDefinition_Read_DefaultsToRootFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetDefinitions(clients.Ctx, build.GetDefinitionsArgs{
+			Project: converter.String(testDataBuildDefinitionProjectID),
+			Name:    converter.String("Name"),
+			Path:    converter.String(`\`),
+		}).
+		Return(&build.GetDefinitionsResponseValue{}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataBuildDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataBuildDefinitionProjectID)
+	resourceData.Set("name", "Name")
+
+	err := dataSourceBuildDefinitionRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "not found")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestDataSourceBuildDefinition_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetDefinitions(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetDefinitions() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataBuildDefinition().Schema, nil)
+	resourceData.Set("project_id", testDataBuildDefinitionProjectID)
+	resourceData.Set("name", "Name")
+
+	err := dataSourceBuildDefinitionRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetDefinitions() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a build definition can be looked up by its name
+func TestAccDataSourceBuildDefinition_LookupByName(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "data.azuredevops_build_definition.build"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionDataSource(projectName, buildDefinitionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(tfNode, "id", "azuredevops_build_definition.build", "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "revision", "azuredevops_build_definition.build", "revision"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.yml_path", "path/to/yaml"),
+				),
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,233 @@
+package azuredevops
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func dataBuilds() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildsRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"branch_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildStatusValues.InProgress),
+					string(build.BuildStatusValues.Completed),
+					string(build.BuildStatusValues.Cancelling),
+					string(build.BuildStatusValues.Postponed),
+					string(build.BuildStatusValues.NotStarted),
+					string(build.BuildStatusValues.All),
+				}, false),
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildResultValues.Succeeded),
+					string(build.BuildResultValues.PartiallySucceeded),
+					string(build.BuildResultValues.Failed),
+					string(build.BuildResultValues.Canceled),
+				}, false),
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntBetween(1, 5000),
+			},
+			"builds": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"build_number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"definition_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"source_branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"result": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"finish_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	args := expandBuildsQuery(d)
+	builds, err := clients.BuildClient.GetBuilds(clients.Ctx, args)
+	if err != nil {
+		return fmt.Errorf("Error finding builds in project %s. Error: %v", projectID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] builds from project [%s]", len(builds.Value), projectID)
+
+	id, err := getBuildsQueryID(args)
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+	return d.Set("builds", flattenBuilds(builds.Value))
+}
+
+// the builds are ordered by their finish time, so that the first build is the most recent one
+func expandBuildsQuery(d *schema.ResourceData) build.GetBuildsArgs {
+	args := build.GetBuildsArgs{
+		Project:    converter.String(d.Get("project_id").(string)),
+		Top:        converter.Int(d.Get("max_results").(int)),
+		QueryOrder: &build.BuildQueryOrderValues.FinishTimeDescending,
+	}
+
+	if definitionID, ok := d.GetOk("definition_id"); ok {
+		args.Definitions = &[]int{definitionID.(int)}
+	}
+	if branchName, ok := d.GetOk("branch_name"); ok {
+		args.BranchName = converter.String(branchName.(string))
+	}
+	if status, ok := d.GetOk("status"); ok {
+		statusFilter := build.BuildStatus(status.(string))
+		args.StatusFilter = &statusFilter
+	}
+	if result, ok := d.GetOk("result"); ok {
+		resultFilter := build.BuildResult(result.(string))
+		args.ResultFilter = &resultFilter
+	}
+	if tags := d.Get("tags").(*schema.Set); tags.Len() > 0 {
+		tagFilters := []string{}
+		for _, tag := range tags.List() {
+			tagFilters = append(tagFilters, tag.(string))
+		}
+		args.TagFilters = &tagFilters
+	}
+	return args
+}
+
+// the ID is a hash of all filters of the query, so that queries which differ in any filter have different IDs
+func getBuildsQueryID(args build.GetBuildsArgs) (string, error) {
+	definitionID := ""
+	if args.Definitions != nil {
+		definitionID = strconv.Itoa((*args.Definitions)[0])
+	}
+	status := ""
+	if args.StatusFilter != nil {
+		status = string(*args.StatusFilter)
+	}
+	result := ""
+	if args.ResultFilter != nil {
+		result = string(*args.ResultFilter)
+	}
+	tags := []string{}
+	if args.TagFilters != nil {
+		tags = append(tags, *args.TagFilters...)
+		sort.Strings(tags)
+	}
+
+	h := sha1.New()
+	query := fmt.Sprintf("%s#%s#%s#%s#%s#%s#%d", *args.Project, definitionID, converter.ToString(args.BranchName, ""), status, result, strings.Join(tags, ","), converter.ToInt(args.Top, 0))
+	if _, err := h.Write([]byte(query)); err != nil {
+		return "", fmt.Errorf("Unable to compute hash for build filter: %v", err)
+	}
+	return "builds#" + base64.URLEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+func flattenBuilds(builds []build.Build) []interface{} {
+	results := make([]interface{}, 0, len(builds))
+	for _, b := range builds {
+		definitionID := 0
+		if b.Definition != nil {
+			definitionID = converter.ToInt(b.Definition.Id, 0)
+		}
+
+		status := ""
+		if b.Status != nil {
+			status = string(*b.Status)
+		}
+		result := ""
+		if b.Result != nil {
+			result = string(*b.Result)
+		}
+		finishTime := ""
+		if b.FinishTime != nil {
+			finishTime = b.FinishTime.Time.Format(time.RFC3339)
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":             converter.ToInt(b.Id, 0),
+			"build_number":   converter.ToString(b.BuildNumber, ""),
+			"definition_id":  definitionID,
+			"source_branch":  converter.ToString(b.SourceBranch, ""),
+			"source_version": converter.ToString(b.SourceVersion, ""),
+			"status":         status,
+			"result":         result,
+			"finish_time":    finishTime,
+		})
+	}
+	return results
+}
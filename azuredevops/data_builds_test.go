@@ -0,0 +1,189 @@
+// +build all data_builds
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuildsProjectID = uuid.New().String()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the filters are passed to AzDO and the most recent builds are returned first
+func TestDataSourceBuilds_Read_FiltersBuilds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	finishTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectedArgs := build.GetBuildsArgs{
+		Project:      converter.String(testBuildsProjectID),
+		Definitions:  &[]int{10},
+		BranchName:   converter.String("refs/heads/master"),
+		StatusFilter: &build.BuildStatusValues.Completed,
+		ResultFilter: &build.BuildResultValues.Succeeded,
+		TagFilters:   &[]string{"release"},
+		Top:          converter.Int(1),
+		QueryOrder:   &build.BuildQueryOrderValues.FinishTimeDescending,
+	}
+	buildClient.
+		EXPECT().
+		GetBuilds(clients.Ctx, expectedArgs).
+		Return(&build.GetBuildsResponseValue{
+			Value: []build.Build{
+				{
+					Id:            converter.Int(1234),
+					BuildNumber:   converter.String("20200102.1"),
+					Definition:    &build.DefinitionReference{Id: converter.Int(10)},
+					SourceBranch:  converter.String("refs/heads/master"),
+					SourceVersion: converter.String("abc123"),
+					Status:        &build.BuildStatusValues.Completed,
+					Result:        &build.BuildResultValues.Succeeded,
+					FinishTime:    &azuredevops.Time{Time: finishTime},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataBuilds().Schema, nil)
+	resourceData.Set("project_id", testBuildsProjectID)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("branch_name", "refs/heads/master")
+	resourceData.Set("status", "completed")
+	resourceData.Set("result", "succeeded")
+	resourceData.Set("tags", []interface{}{"release"})
+	resourceData.Set("max_results", 1)
+
+	err := dataSourceBuildsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.NotEqual(t, "", resourceData.Id())
+	require.Equal(t, 1, resourceData.Get("builds.#"))
+	require.Equal(t, 1234, resourceData.Get("builds.0.id"))
+	require.Equal(t, "20200102.1", resourceData.Get("builds.0.build_number"))
+	require.Equal(t, 10, resourceData.Get("builds.0.definition_id"))
+	require.Equal(t, "abc123", resourceData.Get("builds.0.source_version"))
+	require.Equal(t, "succeeded", resourceData.Get("builds.0.result"))
+	require.Equal(t, "2020-01-02T03:04:05Z", resourceData.Get("builds.0.finish_time"))
+}
+
+// verifies that queries which only differ in their tags have different IDs, independent of the order of the tags
+func TestDataSourceBuilds_QueryIDIncludesTags(t *testing.T) {
+	args := build.GetBuildsArgs{Project: converter.String(testBuildsProjectID), TagFilters: &[]string{"release", "nightly"}}
+	id, err := getBuildsQueryID(args)
+	require.Nil(t, err)
+
+	args.TagFilters = &[]string{"nightly", "release"}
+	sameID, err := getBuildsQueryID(args)
+	require.Nil(t, err)
+	require.Equal(t, id, sameID)
+
+	args.TagFilters = &[]string{"release"}
+	otherID, err := getBuildsQueryID(args)
+	require.Nil(t, err)
+	require.NotEqual(t, id, otherID)
+
+	args.TagFilters = nil
+	untaggedID, err := getBuildsQueryID(args)
+	require.Nil(t, err)
+	require.NotEqual(t, otherID, untaggedID)
+}
+
+// verifies that builds which have not finished yet are returned without a finish time
+func TestDataSourceBuilds_Read_UnfinishedBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuilds(clients.Ctx, build.GetBuildsArgs{
+			Project:    converter.String(testBuildsProjectID),
+			Top:        converter.Int(50),
+			QueryOrder: &build.BuildQueryOrderValues.FinishTimeDescending,
+		}).
+		Return(&build.GetBuildsResponseValue{
+			Value: []build.Build{{Id: converter.Int(1), Status: &build.BuildStatusValues.InProgress}},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataBuilds().Schema, nil)
+	resourceData.Set("project_id", testBuildsProjectID)
+
+	err := dataSourceBuildsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "inProgress", resourceData.Get("builds.0.status"))
+	require.Equal(t, "", resourceData.Get("builds.0.result"))
+	require.Equal(t, "", resourceData.Get("builds.0.finish_time"))
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestDataSourceBuilds_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuilds(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetBuilds() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataBuilds().Schema, nil)
+	resourceData.Set("project_id", testBuildsProjectID)
+
+	err := dataSourceBuildsRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetBuilds() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that the builds of a build definition can be listed, a new definition has no builds yet
+func TestAccDataSourceBuilds_ListsBuildsOfDefinition(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "data.azuredevops_builds.builds"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildsDataSource(projectName, buildDefinitionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "builds.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
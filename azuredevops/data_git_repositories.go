@@ -0,0 +1,166 @@
+package azuredevops
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func dataGitRepositories() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGitRepositoriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"name": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Optional:         true,
+				ValidateFunc:     validate.NoEmptyStrings,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+			"include_hidden": {
+				Type:     schema.TypeBool,
+				ForceNew: true,
+				Optional: true,
+				Default:  false,
+			},
+			"repositories": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Set:      getGitRepositoryHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_fork": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"remote_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ssh_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"web_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getGitRepositoryHash(v interface{}) int {
+	return hashcode.String(v.(map[string]interface{})["id"].(string))
+}
+
+func dataSourceGitRepositoriesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	name := d.Get("name").(string)
+	includeHidden := d.Get("include_hidden").(bool)
+
+	repos, err := getGitRepositoriesForProjectAndName(clients, projectID, name, includeHidden)
+	if err != nil {
+		return fmt.Errorf("Error finding repositories in project %s. Error: %v", projectID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] repositories from project [%s]", len(repos), projectID)
+
+	results := flattenGitRepositories(repos)
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(fmt.Sprintf("%s#%s#%t", projectID, name, includeHidden))); err != nil {
+		return fmt.Errorf("Unable to compute hash for repository filter: %v", err)
+	}
+	d.SetId("gitRepos#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	err = d.Set("repositories", results)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func getGitRepositoriesForProjectAndName(clients *config.AggregatedClient, projectID string, repoName string, includeHidden bool) ([]git.GitRepository, error) {
+	args := git.GetRepositoriesArgs{
+		IncludeHidden: converter.Bool(includeHidden),
+	}
+	if projectID != "" {
+		args.Project = converter.String(projectID)
+	}
+
+	repos, err := clients.GitReposClient.GetRepositories(clients.Ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoName == "" {
+		return *repos, nil
+	}
+
+	for _, repo := range *repos {
+		if strings.EqualFold(converter.ToString(repo.Name, ""), repoName) {
+			return []git.GitRepository{repo}, nil
+		}
+	}
+
+	return []git.GitRepository{}, nil
+}
+
+// Convert a list of repositories to the same set of attributes that is exposed by the git repository resource
+func flattenGitRepositories(repos []git.GitRepository) []interface{} {
+	results := make([]interface{}, 0, len(repos))
+
+	for _, repo := range repos {
+		output := flattenAzureGitRepositoryAttributes(&repo)
+		if repo.Id != nil {
+			output["id"] = repo.Id.String()
+		}
+
+		results = append(results, output)
+	}
+
+	return results
+}
@@ -0,0 +1,150 @@
+// +build all core data_git_repositories
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testGitReposProjectID = uuid.New()
+var testGitReposIDs = []uuid.UUID{uuid.New(), uuid.New()}
+
+var testGitRepos = []git.GitRepository{
+	{
+		Id:      &testGitReposIDs[0],
+		Name:    converter.String("repo-one"),
+		Project: &core.TeamProjectReference{Id: &testGitReposProjectID},
+	},
+	{
+		Id:      &testGitReposIDs[1],
+		Name:    converter.String("repo-two"),
+		Project: &core.TeamProjectReference{Id: &testGitReposProjectID},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that all repositories of the project are returned if no name is set
+func TestDataSourceGitRepositories_Read_ReturnsAllRepositories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	expectedArgs := git.GetRepositoriesArgs{
+		Project:       converter.String(testGitReposProjectID.String()),
+		IncludeHidden: converter.Bool(true),
+	}
+	reposClient.
+		EXPECT().
+		GetRepositories(clients.Ctx, expectedArgs).
+		Return(&testGitRepos, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataGitRepositories().Schema, nil)
+	resourceData.Set("project_id", testGitReposProjectID.String())
+	resourceData.Set("include_hidden", true)
+
+	err := dataSourceGitRepositoriesRead(resourceData, clients)
+	require.Nil(t, err)
+	require.NotEqual(t, "", resourceData.Id())
+
+	repos := resourceData.Get("repositories").(*schema.Set).List()
+	require.Equal(t, len(testGitRepos), len(repos))
+}
+
+// verifies that the name filter is case insensitive and only returns the matching repository
+func TestDataSourceGitRepositories_Read_FiltersByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.
+		EXPECT().
+		GetRepositories(clients.Ctx, gomock.Any()).
+		Return(&testGitRepos, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataGitRepositories().Schema, nil)
+	resourceData.Set("project_id", testGitReposProjectID.String())
+	resourceData.Set("name", "REPO-TWO")
+
+	err := dataSourceGitRepositoriesRead(resourceData, clients)
+	require.Nil(t, err)
+
+	repos := resourceData.Get("repositories").(*schema.Set).List()
+	require.Equal(t, 1, len(repos))
+	repo := repos[0].(map[string]interface{})
+	require.Equal(t, testGitReposIDs[1].String(), repo["id"])
+	require.Equal(t, "repo-two", repo["name"])
+	require.Equal(t, testGitReposProjectID.String(), repo["project_id"])
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestDataSourceGitRepositories_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.
+		EXPECT().
+		GetRepositories(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetRepositories() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataGitRepositories().Schema, nil)
+	resourceData.Set("project_id", testGitReposProjectID.String())
+
+	err := dataSourceGitRepositoriesRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetRepositories() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a repository created in the same configuration can be found by name
+func TestAccDataSourceGitRepositories_FindByName(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "data.azuredevops_git_repositories.repositories"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccGitRepositoriesDataSource(projectName, gitRepoName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "repositories.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,100 @@
+package azuredevops
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func dataGitRepositoryRefs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGitRepositoryRefsRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Default:  "",
+			},
+			"refs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"commit_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_locked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGitRepositoryRefsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID := d.Get("repository_id").(string)
+
+	// the API expects the filter without the `refs/` prefix, e.g. `heads/` or `tags/v1`
+	filter := strings.TrimPrefix(d.Get("filter").(string), "refs/")
+
+	refs, err := getGitRefs(clients, repoID, filter)
+	if err != nil {
+		return fmt.Errorf("Error finding refs with filter %s in repository %s. Error: %v", filter, repoID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] refs from repository [%s]", len(refs), repoID)
+
+	d.SetId(fmt.Sprintf("%s:refs/%s", repoID, filter))
+	err = d.Set("refs", flattenGitRefs(refs))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func flattenGitRefs(refs []git.GitRef) []interface{} {
+	results := make([]interface{}, 0, len(refs))
+
+	for _, ref := range refs {
+		// annotated tags are peeled to the commit they point at, all other refs point at the commit directly
+		commitID := converter.ToString(ref.PeeledObjectId, "")
+		if commitID == "" {
+			commitID = converter.ToString(ref.ObjectId, "")
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":      converter.ToString(ref.Name, ""),
+			"object_id": converter.ToString(ref.ObjectId, ""),
+			"commit_id": commitID,
+			"is_locked": converter.ToBool(ref.IsLocked, false),
+		})
+	}
+
+	return results
+}
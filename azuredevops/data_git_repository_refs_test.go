@@ -0,0 +1,129 @@
+// +build all core data_git_repository_refs
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testRefsRepoID = uuid.New().String()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that all pages of refs are read and annotated tags are resolved to their commit
+func TestDataSourceGitRepositoryRefs_Read_ReadsAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	firstPageArgs := git.GetRefsArgs{
+		RepositoryId: converter.String(testRefsRepoID),
+		Filter:       converter.String("tags/"),
+		PeelTags:     converter.Bool(true),
+	}
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, firstPageArgs).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/tags/v1"), ObjectId: converter.String("commit1")},
+			},
+			ContinuationToken: "token",
+		}, nil).
+		Times(1)
+
+	secondPageArgs := firstPageArgs
+	secondPageArgs.ContinuationToken = converter.String("token")
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, secondPageArgs).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/tags/v2"), ObjectId: converter.String("tag2"), PeeledObjectId: converter.String("commit2")},
+			},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataGitRepositoryRefs().Schema, nil)
+	resourceData.Set("repository_id", testRefsRepoID)
+	resourceData.Set("filter", "refs/tags/")
+
+	err := dataSourceGitRepositoryRefsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testRefsRepoID+":refs/tags/", resourceData.Id())
+
+	require.Equal(t, 2, resourceData.Get("refs.#"))
+	require.Equal(t, "refs/tags/v1", resourceData.Get("refs.0.name"))
+	require.Equal(t, "commit1", resourceData.Get("refs.0.commit_id"))
+	require.Equal(t, "tag2", resourceData.Get("refs.1.object_id"))
+	require.Equal(t, "commit2", resourceData.Get("refs.1.commit_id"))
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestDataSourceGitRepositoryRefs_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetRefs() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataGitRepositoryRefs().Schema, nil)
+	resourceData.Set("repository_id", testRefsRepoID)
+
+	err := dataSourceGitRepositoryRefsRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetRefs() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that the branch of a freshly initialized repository is returned
+func TestAccDataSourceGitRepositoryRefs_ListBranches(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "data.azuredevops_git_repository_refs.refs"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccGitRepositoryRefsDataSource(projectName, gitRepoName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "refs.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "refs.0.name", "refs/heads/master"),
+					resource.TestCheckResourceAttrSet(tfNode, "refs.0.commit_id"),
+				),
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,88 @@
+package azuredevops
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func dataPolicyTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePolicyTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"policy_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyTypesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	policyTypes, err := clients.PolicyClient.GetPolicyTypes(clients.Ctx, policy.GetPolicyTypesArgs{
+		Project: converter.String(projectID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error finding policy types for project %s. Error: %v", projectID, err)
+	}
+
+	results := flattenPolicyTypes(policyTypes)
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] policy types from project [%s]", len(results), projectID)
+
+	d.SetId("policyTypes#" + projectID)
+	err = d.Set("policy_types", results)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func flattenPolicyTypes(policyTypes *[]policy.PolicyType) []interface{} {
+	if policyTypes == nil {
+		return []interface{}{}
+	}
+
+	results := make([]interface{}, 0, len(*policyTypes))
+	for _, policyType := range *policyTypes {
+		output := map[string]interface{}{
+			"display_name": converter.ToString(policyType.DisplayName, ""),
+			"description":  converter.ToString(policyType.Description, ""),
+		}
+		if policyType.Id != nil {
+			output["id"] = policyType.Id.String()
+		}
+		results = append(results, output)
+	}
+	return results
+}
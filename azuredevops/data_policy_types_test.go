@@ -0,0 +1,137 @@
+// +build all core data_policy_types
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicyTypesProjectID = uuid.New().String()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the policy types of the project are listed
+func TestDataSourcePolicyTypes_Read_ListsPolicyTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	expectedArgs := policy.GetPolicyTypesArgs{Project: converter.String(testPolicyTypesProjectID)}
+	policyClient.
+		EXPECT().
+		GetPolicyTypes(clients.Ctx, expectedArgs).
+		Return(&[]policy.PolicyType{
+			{
+				Id:          &minReviewersPolicyTypeID,
+				DisplayName: converter.String("Minimum number of reviewers"),
+				Description: converter.String("This policy will ensure that a minimum number of reviewers have approved a pull request before completion."),
+			},
+			{
+				Id:          &buildValidationPolicyTypeID,
+				DisplayName: converter.String("Build"),
+			},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataPolicyTypes().Schema, nil)
+	resourceData.Set("project_id", testPolicyTypesProjectID)
+
+	err := dataSourcePolicyTypesRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "policyTypes#"+testPolicyTypesProjectID, resourceData.Id())
+
+	require.Equal(t, 2, resourceData.Get("policy_types.#"))
+	require.Equal(t, minReviewersPolicyTypeID.String(), resourceData.Get("policy_types.0.id"))
+	require.Equal(t, "Minimum number of reviewers", resourceData.Get("policy_types.0.display_name"))
+	require.Equal(t, buildValidationPolicyTypeID.String(), resourceData.Get("policy_types.1.id"))
+	require.Equal(t, "", resourceData.Get("policy_types.1.description"))
+}
+
+// verifies that an empty response of the service does not fail the read
+func TestDataSourcePolicyTypes_Read_HandlesEmptyResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		GetPolicyTypes(clients.Ctx, gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataPolicyTypes().Schema, nil)
+	resourceData.Set("project_id", testPolicyTypesProjectID)
+
+	err := dataSourcePolicyTypesRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, 0, resourceData.Get("policy_types.#"))
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestDataSourcePolicyTypes_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		GetPolicyTypes(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetPolicyTypes() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, dataPolicyTypes().Schema, nil)
+	resourceData.Set("project_id", testPolicyTypesProjectID)
+
+	err := dataSourcePolicyTypesRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetPolicyTypes() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that the built-in policy types are listed for a new project
+func TestAccDataSourcePolicyTypes_ListsBuiltInTypes(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "data.azuredevops_policy_types.types"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccPolicyTypesDataSource(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "policy_types.0.id"),
+					resource.TestCheckResourceAttrSet(tfNode, "policy_types.0.display_name"),
+				),
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,56 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+)
+
+// The helpers in this file are shared by the acceptance tests of all policy resources
+
+// Given the name of a policy resource, this will return a function that builds the `projectId/policyId` import ID
+func testAccBranchPolicyImportStateIDFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Did not find a policy in the TF state")
+		}
+		return fmt.Sprintf("%s/%s", res.Primary.Attributes["project_id"], res.Primary.ID), nil
+	}
+}
+
+// Given the type of a policy resource, this will return a function that verifies that no policy of this type
+// referenced in the state exists in AzDO
+func testAccBranchPolicyCheckDestroy(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		clients := testAccProvider.Meta().(*config.AggregatedClient)
+
+		for _, res := range s.RootModule().Resources {
+			if res.Type != resourceType {
+				continue
+			}
+
+			policyID, err := strconv.Atoi(res.Primary.ID)
+			if err != nil {
+				return fmt.Errorf("Policy ID=%s cannot be parsed. Error=%v", res.Primary.ID, err)
+			}
+
+			// the project is destroyed as well, so a failed lookup is fine here
+			projectID := res.Primary.Attributes["project_id"]
+			policyConfig, err := clients.PolicyClient.GetPolicyConfiguration(clients.Ctx, policy.GetPolicyConfigurationArgs{
+				Project:         &projectID,
+				ConfigurationId: &policyID,
+			})
+			if err == nil && !converter.ToBool(policyConfig.IsDeleted, false) {
+				return fmt.Errorf("Policy ID %d should not exist", policyID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -9,19 +9,41 @@ import (
 func Provider() *schema.Provider {
 	p := &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
-			"azuredevops_build_definition":          resourceBuildDefinition(),
-			"azuredevops_project":                   resourceProject(),
-			"azuredevops_variable_group":            resourceVariableGroup(),
-			"azuredevops_serviceendpoint_github":    resourceServiceEndpointGitHub(),
-			"azuredevops_serviceendpoint_dockerhub": resourceServiceEndpointDockerHub(),
-			"azuredevops_azure_git_repository":      resourceAzureGitRepository(),
-			"azuredevops_user_entitlement":          resourceUserEntitlement(),
-			"azuredevops_group_membership":          resourceGroupMembership(),
-			"azuredevops_agent_pool":                resourceAzureAgentPool(),
+			"azuredevops_build_definition":                   resourceBuildDefinition(),
+			"azuredevops_build_folder":                       resourceBuildFolder(),
+			"azuredevops_build_retention_settings":           resourceBuildRetentionSettings(),
+			"azuredevops_build_run":                          resourceBuildRun(),
+			"azuredevops_project":                            resourceProject(),
+			"azuredevops_variable_group":                     resourceVariableGroup(),
+			"azuredevops_serviceendpoint_github":             resourceServiceEndpointGitHub(),
+			"azuredevops_serviceendpoint_dockerhub":          resourceServiceEndpointDockerHub(),
+			"azuredevops_azure_git_repository":               resourceAzureGitRepository(),
+			"azuredevops_user_entitlement":                   resourceUserEntitlement(),
+			"azuredevops_group_membership":                   resourceGroupMembership(),
+			"azuredevops_agent_pool":                         resourceAzureAgentPool(),
+			"azuredevops_git_repository_branch":              resourceGitRepositoryBranch(),
+			"azuredevops_git_repository_tag":                 resourceGitRepositoryTag(),
+			"azuredevops_branch_policy_min_reviewers":        resourceBranchPolicyMinReviewers(),
+			"azuredevops_branch_policy_build_validation":     resourceBranchPolicyBuildValidation(),
+			"azuredevops_branch_policy_auto_reviewers":       resourceBranchPolicyAutoReviewers(),
+			"azuredevops_branch_policy_comment_resolution":   resourceBranchPolicyCommentResolution(),
+			"azuredevops_branch_policy_work_item_linking":    resourceBranchPolicyWorkItemLinking(),
+			"azuredevops_branch_policy_merge_types":          resourceBranchPolicyMergeTypes(),
+			"azuredevops_branch_policy_status_check":         resourceBranchPolicyStatusCheck(),
+			"azuredevops_repository_policy_max_file_size":    resourceRepositoryPolicyMaxFileSize(),
+			"azuredevops_repository_policy_max_path_length":  resourceRepositoryPolicyMaxPathLength(),
+			"azuredevops_repository_policy_reserved_names":   resourceRepositoryPolicyReservedNames(),
+			"azuredevops_repository_policy_case_enforcement": resourceRepositoryPolicyCaseEnforcement(),
+			"azuredevops_policy_configuration":               resourcePolicyConfiguration(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"azuredevops_group":    dataGroup(),
-			"azuredevops_projects": dataProjects(),
+			"azuredevops_group":               dataGroup(),
+			"azuredevops_projects":            dataProjects(),
+			"azuredevops_git_repositories":    dataGitRepositories(),
+			"azuredevops_git_repository_refs": dataGitRepositoryRefs(),
+			"azuredevops_policy_types":        dataPolicyTypes(),
+			"azuredevops_build_definition":    dataBuildDefinition(),
+			"azuredevops_builds":              dataBuilds(),
 		},
 		Schema: map[string]*schema.Schema{
 			"org_service_url": {
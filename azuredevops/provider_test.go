@@ -17,6 +17,9 @@ var provider = Provider()
 func TestAzureDevOpsProvider_HasChildResources(t *testing.T) {
 	expectedResources := []string{
 		"azuredevops_build_definition",
+		"azuredevops_build_folder",
+		"azuredevops_build_retention_settings",
+		"azuredevops_build_run",
 		"azuredevops_project",
 		"azuredevops_serviceendpoint_github",
 		"azuredevops_serviceendpoint_dockerhub",
@@ -25,6 +28,20 @@ func TestAzureDevOpsProvider_HasChildResources(t *testing.T) {
 		"azuredevops_user_entitlement",
 		"azuredevops_group_membership",
 		"azuredevops_agent_pool",
+		"azuredevops_git_repository_branch",
+		"azuredevops_git_repository_tag",
+		"azuredevops_branch_policy_min_reviewers",
+		"azuredevops_branch_policy_build_validation",
+		"azuredevops_branch_policy_auto_reviewers",
+		"azuredevops_branch_policy_comment_resolution",
+		"azuredevops_branch_policy_work_item_linking",
+		"azuredevops_branch_policy_merge_types",
+		"azuredevops_branch_policy_status_check",
+		"azuredevops_repository_policy_max_file_size",
+		"azuredevops_repository_policy_max_path_length",
+		"azuredevops_repository_policy_reserved_names",
+		"azuredevops_repository_policy_case_enforcement",
+		"azuredevops_policy_configuration",
 	}
 
 	resources := provider.ResourcesMap
@@ -40,6 +57,11 @@ func TestAzureDevOpsProvider_HasChildDataSources(t *testing.T) {
 	expectedDataSources := []string{
 		"azuredevops_group",
 		"azuredevops_projects",
+		"azuredevops_git_repositories",
+		"azuredevops_git_repository_refs",
+		"azuredevops_policy_types",
+		"azuredevops_build_definition",
+		"azuredevops_builds",
 	}
 
 	dataSources := provider.DataSourcesMap
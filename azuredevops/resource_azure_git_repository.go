@@ -2,13 +2,17 @@ package azuredevops
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/gitext"
 )
 
 func resourceAzureGitRepository() *schema.Resource {
@@ -56,6 +60,16 @@ func resourceAzureGitRepository() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"is_disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"restore_from_recycle_bin": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"initialization": {
 				Type:     schema.TypeSet,
 				Required: true,
@@ -99,20 +113,47 @@ func resourceAzureGitRepositoryCreate(d *schema.ResourceData, m interface{}) err
 		return fmt.Errorf("Error expanding repository resource data: %+v", err)
 	}
 
-	createdRepo, err := createAzureGitRepository(clients, repo.Name, projectID)
-	if err != nil {
-		return fmt.Errorf("Error creating repository in Azure DevOps: %+v", err)
+	var createdRepo *git.GitRepository
+	// a new repository is always enabled, while a restored repository keeps the status it had when it was deleted
+	isDisabled := false
+	if d.Get("restore_from_recycle_bin").(bool) {
+		createdRepo, err = restoreAzureGitRepository(clients, *repo.Name, projectID)
+		if err != nil {
+			return fmt.Errorf("Error restoring repository from the recycle bin in Azure DevOps: %+v", err)
+		}
+
+		if createdRepo != nil {
+			isDisabled, err = azureGitRepositoryIsDisabledRead(clients, createdRepo)
+			if err != nil {
+				return fmt.Errorf("Error looking up status of restored repository in Azure DevOps: %+v", err)
+			}
+		}
 	}
 
-	if initialization.initType == "Clean" {
-		err = initializeAzureGitRepository(clients, createdRepo)
+	// a restored repository keeps its content, so it must not be initialized again
+	if createdRepo == nil {
+		createdRepo, err = createAzureGitRepository(clients, repo.Name, projectID)
 		if err != nil {
-			return fmt.Errorf("Error initializing repository in Azure DevOps: %+v", err)
+			return fmt.Errorf("Error creating repository in Azure DevOps: %+v", err)
+		}
+
+		if initialization.initType == "Clean" {
+			err = initializeAzureGitRepository(clients, createdRepo)
+			if err != nil {
+				return fmt.Errorf("Error initializing repository in Azure DevOps: %+v", err)
+			}
 		}
 	}
 
 	flattenAzureGitRepository(d, createdRepo)
 
+	if d.Get("is_disabled").(bool) != isDisabled {
+		err = updateAzureGitRepositoryIsDisabled(clients, createdRepo.Id, projectID, !isDisabled)
+		if err != nil {
+			return fmt.Errorf("Error updating status of repository in Azure DevOps: %+v", err)
+		}
+	}
+
 	return resourceAzureGitRepositoryRead(d, m)
 }
 
@@ -130,6 +171,41 @@ func createAzureGitRepository(clients *config.AggregatedClient, repoName *string
 	return createdRepository, err
 }
 
+// Restore the deleted repository with the given name from the recycle bin of the project. Returns nil
+// if the recycle bin does not contain a repository with that name.
+func restoreAzureGitRepository(clients *config.AggregatedClient, repoName string, projectID *uuid.UUID) (*git.GitRepository, error) {
+	deletedRepos, err := clients.GitReposClient.GetRecycleBinRepositories(clients.Ctx, git.GetRecycleBinRepositoriesArgs{
+		Project: converter.String(projectID.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deletedRepo := range *deletedRepos {
+		if !strings.EqualFold(converter.ToString(deletedRepo.Name, ""), repoName) {
+			continue
+		}
+
+		restoredRepo, err := clients.GitReposClient.RestoreRepositoryFromRecycleBin(clients.Ctx, git.RestoreRepositoryFromRecycleBinArgs{
+			Project:      converter.String(projectID.String()),
+			RepositoryId: deletedRepo.Id,
+			RepositoryDetails: &git.GitRecycleBinRepositoryDetails{
+				Deleted: converter.Bool(false),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if restoredRepo.Project == nil {
+			restoredRepo.Project = deletedRepo.Project
+		}
+		return restoredRepo, nil
+	}
+
+	return nil, nil
+}
+
 func initializeAzureGitRepository(clients *config.AggregatedClient, repo *git.GitRepository) error {
 	args := git.CreatePushArgs{
 		RepositoryId: repo.Name,
@@ -174,13 +250,35 @@ func resourceAzureGitRepositoryRead(d *schema.ResourceData, m interface{}) error
 	clients := m.(*config.AggregatedClient)
 	repo, err := azureGitRepositoryRead(clients, repoID, repoName, projectID)
 	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error looking up repository with ID %s and Name %s. Error: %v", repoID, repoName, err)
 	}
 
+	isDisabled, err := azureGitRepositoryIsDisabledRead(clients, repo)
+	if err != nil {
+		return fmt.Errorf("Error looking up status of repository with ID %s and Name %s. Error: %v", repoID, repoName, err)
+	}
+
 	flattenAzureGitRepository(d, repo)
+	d.Set("is_disabled", isDisabled)
 	return nil
 }
 
+// The git SDK models do not expose the disabled status of a repository, so it has to be looked up separately
+func azureGitRepositoryIsDisabledRead(clients *config.AggregatedClient, repo *git.GitRepository) (bool, error) {
+	extRepo, err := clients.GitReposExtClient.GetRepository(clients.Ctx, gitext.GetRepositoryArgs{
+		RepositoryId: converter.String(repo.Id.String()),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return converter.ToBool(extRepo.IsDisabled, false), nil
+}
+
 func resourceAzureGitRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*config.AggregatedClient)
 	repo, _, projectID, err := expandAzureGitRepository(d)
@@ -188,12 +286,34 @@ func resourceAzureGitRepositoryUpdate(d *schema.ResourceData, m interface{}) err
 		return fmt.Errorf("Error converting terraform data model to AzDO project reference: %+v", err)
 	}
 
-	repo, err = updateAzureGitRepository(clients, repo, projectID)
-	if err != nil {
-		return fmt.Errorf("Error updating repository in Azure DevOps: %+v", err)
+	// a disabled repository rejects all other changes, so it has to be enabled first and disabled last.
+	// A repository that stays disabled is only enabled for the time it takes to rename it.
+	isDisabled := d.Get("is_disabled").(bool)
+	staysDisabled := isDisabled && !d.HasChange("is_disabled")
+	isRenamedWhileDisabled := staysDisabled && d.HasChange("name")
+	if (d.HasChange("is_disabled") && !isDisabled) || isRenamedWhileDisabled {
+		err = updateAzureGitRepositoryIsDisabled(clients, repo.Id, projectID, false)
+		if err != nil {
+			return fmt.Errorf("Error enabling repository in Azure DevOps: %+v", err)
+		}
+	}
+
+	if !staysDisabled || isRenamedWhileDisabled {
+		repo, err = updateAzureGitRepository(clients, repo, projectID)
+		if err != nil {
+			return fmt.Errorf("Error updating repository in Azure DevOps: %+v", err)
+		}
+
+		flattenAzureGitRepository(d, repo)
+	}
+
+	if (d.HasChange("is_disabled") && isDisabled) || isRenamedWhileDisabled {
+		err = updateAzureGitRepositoryIsDisabled(clients, repo.Id, projectID, true)
+		if err != nil {
+			return fmt.Errorf("Error disabling repository in Azure DevOps: %+v", err)
+		}
 	}
 
-	flattenAzureGitRepository(d, repo)
 	return resourceAzureGitRepositoryRead(d, m)
 }
 
@@ -208,6 +328,18 @@ func updateAzureGitRepository(clients *config.AggregatedClient, repository *git.
 		})
 }
 
+func updateAzureGitRepositoryIsDisabled(clients *config.AggregatedClient, repoID *uuid.UUID, project *uuid.UUID, isDisabled bool) error {
+	_, err := clients.GitReposExtClient.UpdateRepositoryIsDisabled(
+		clients.Ctx,
+		gitext.UpdateRepositoryIsDisabledArgs{
+			IsDisabled:   converter.Bool(isDisabled),
+			RepositoryId: repoID,
+			Project:      converter.String(project.String()),
+		})
+
+	return err
+}
+
 func resourceAzureGitRepositoryDelete(d *schema.ResourceData, m interface{}) error {
 	repoID := d.Id()
 	clients := m.(*config.AggregatedClient)
@@ -241,15 +373,32 @@ func azureGitRepositoryRead(clients *config.AggregatedClient, repoID string, rep
 func flattenAzureGitRepository(d *schema.ResourceData, repository *git.GitRepository) {
 	d.SetId(repository.Id.String())
 
-	d.Set("name", converter.ToString(repository.Name, ""))
-	d.Set("project_id", repository.Project.Id.String())
-	d.Set("default_branch", converter.ToString(repository.DefaultBranch, ""))
-	d.Set("is_fork", repository.IsFork)
-	d.Set("remote_url", converter.ToString(repository.RemoteUrl, ""))
-	d.Set("size", repository.Size)
-	d.Set("ssh_url", converter.ToString(repository.SshUrl, ""))
-	d.Set("url", converter.ToString(repository.Url, ""))
-	d.Set("web_url", converter.ToString(repository.WebUrl, ""))
+	for key, value := range flattenAzureGitRepositoryAttributes(repository) {
+		d.Set(key, value)
+	}
+}
+
+// Convert a repository to the attributes exposed by the git repository resource and the git repositories data source
+func flattenAzureGitRepositoryAttributes(repository *git.GitRepository) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"name":           converter.ToString(repository.Name, ""),
+		"default_branch": converter.ToString(repository.DefaultBranch, ""),
+		"is_fork":        converter.ToBool(repository.IsFork, false),
+		"remote_url":     converter.ToString(repository.RemoteUrl, ""),
+		"ssh_url":        converter.ToString(repository.SshUrl, ""),
+		"url":            converter.ToString(repository.Url, ""),
+		"web_url":        converter.ToString(repository.WebUrl, ""),
+	}
+
+	if repository.Project != nil && repository.Project.Id != nil {
+		attributes["project_id"] = repository.Project.Id.String()
+	}
+
+	if repository.Size != nil {
+		attributes["size"] = int(*repository.Size)
+	}
+
+	return attributes
 }
 
 // Convert internal Terraform data structure to an AzDO data structure. Note: only the params that are
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
@@ -16,10 +17,12 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/gitext"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
 	"github.com/stretchr/testify/require"
@@ -241,6 +244,335 @@ func TestAzureGitRepo_Read_UsesNameIfIdNotSet(t *testing.T) {
 	resourceAzureGitRepositoryRead(resourceData, clients)
 }
 
+// verifies that a repository that no longer exists is removed from the state instead of failing the read
+func TestAzureGitRepo_Read_ClearsIDIfRepositoryDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{
+		GitReposClient: reposClient,
+		Ctx:            context.Background(),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceAzureGitRepository().Schema, nil)
+	resourceData.SetId("an-id")
+	resourceData.Set("project_id", "a-project")
+
+	statusCode := http.StatusNotFound
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: &statusCode}).
+		Times(1)
+
+	err := resourceAzureGitRepositoryRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the disabled status is read through the extension client
+func TestAzureGitRepo_Read_SetsIsDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	reposExtClient := azdosdkmocks.NewMockGitextClient(ctrl)
+	clients := &config.AggregatedClient{
+		GitReposClient:    reposClient,
+		GitReposExtClient: reposExtClient,
+		Ctx:               context.Background(),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceAzureGitRepository().Schema, nil)
+	resourceData.SetId(testRepoID.String())
+	resourceData.Set("project_id", testRepoProjectID.String())
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&testAzureGitRepository, nil).
+		Times(1)
+
+	expectedArgs := gitext.GetRepositoryArgs{RepositoryId: converter.String(testRepoID.String())}
+	reposExtClient.
+		EXPECT().
+		GetRepository(clients.Ctx, expectedArgs).
+		Return(&gitext.GitRepository{GitRepository: testAzureGitRepository, IsDisabled: converter.Bool(true)}, nil).
+		Times(1)
+
+	err := resourceAzureGitRepositoryRead(resourceData, clients)
+	require.Nil(t, err)
+	require.True(t, resourceData.Get("is_disabled").(bool))
+}
+
+// verifies that a deleted repository with the same name is restored instead of creating a new one
+func TestAzureGitRepo_Create_RestoresRepositoryFromRecycleBin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceAzureGitRepository().Schema, nil)
+	flattenAzureGitRepository(resourceData, &testAzureGitRepository)
+	configureCleanInitialization(resourceData)
+	resourceData.SetId("")
+	resourceData.Set("restore_from_recycle_bin", true)
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	deletedRepoID := uuid.New()
+	reposClient.
+		EXPECT().
+		GetRecycleBinRepositories(clients.Ctx, git.GetRecycleBinRepositoriesArgs{Project: converter.String(testRepoProjectID.String())}).
+		Return(&[]git.GitDeletedRepository{
+			{Id: &deletedRepoID, Name: converter.String("repoName")},
+		}, nil).
+		Times(1)
+
+	expectedArgs := git.RestoreRepositoryFromRecycleBinArgs{
+		Project:           converter.String(testRepoProjectID.String()),
+		RepositoryId:      &deletedRepoID,
+		RepositoryDetails: &git.GitRecycleBinRepositoryDetails{Deleted: converter.Bool(false)},
+	}
+	reposClient.
+		EXPECT().
+		RestoreRepositoryFromRecycleBin(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("RestoreRepositoryFromRecycleBin() Failed")).
+		Times(1)
+
+	reposClient.
+		EXPECT().
+		CreateRepository(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceAzureGitRepositoryCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "RestoreRepositoryFromRecycleBin() Failed")
+}
+
+// verifies that a restored repository is not initialized again, as it keeps its content
+func TestAzureGitRepo_Create_RestoredRepositoryIsNotInitialized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceAzureGitRepository().Schema, nil)
+	flattenAzureGitRepository(resourceData, &testAzureGitRepository)
+	configureCleanInitialization(resourceData)
+	resourceData.SetId("")
+	resourceData.Set("restore_from_recycle_bin", true)
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	reposExtClient := azdosdkmocks.NewMockGitextClient(ctrl)
+	clients := &config.AggregatedClient{
+		GitReposClient:    reposClient,
+		GitReposExtClient: reposExtClient,
+		Ctx:               context.Background(),
+	}
+
+	reposClient.
+		EXPECT().
+		GetRecycleBinRepositories(clients.Ctx, gomock.Any()).
+		Return(&[]git.GitDeletedRepository{
+			{Id: &testRepoID, Name: testAzureGitRepository.Name, Project: testAzureGitRepository.Project},
+		}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		RestoreRepositoryFromRecycleBin(clients.Ctx, gomock.Any()).
+		Return(&git.GitRepository{Id: &testRepoID, Name: testAzureGitRepository.Name}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		CreateRepository(gomock.Any(), gomock.Any()).
+		Times(0)
+	reposClient.
+		EXPECT().
+		CreatePush(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, git.GetRepositoryArgs{
+			RepositoryId: converter.String(testRepoID.String()),
+			Project:      converter.String(testRepoProjectID.String()),
+		}).
+		Return(&testAzureGitRepository, nil).
+		Times(1)
+	reposExtClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&gitext.GitRepository{GitRepository: testAzureGitRepository, IsDisabled: converter.Bool(false)}, nil).
+		Times(2)
+	reposExtClient.
+		EXPECT().
+		UpdateRepositoryIsDisabled(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceAzureGitRepositoryCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testRepoID.String(), resourceData.Id())
+	require.Equal(t, testRepoProjectID.String(), resourceData.Get("project_id"))
+}
+
+// verifies that a repository which was disabled when it was deleted is enabled after it is restored,
+// unless it is configured to be disabled
+func TestAzureGitRepo_Create_EnablesRestoredDisabledRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceAzureGitRepository().Schema, nil)
+	flattenAzureGitRepository(resourceData, &testAzureGitRepository)
+	configureCleanInitialization(resourceData)
+	resourceData.SetId("")
+	resourceData.Set("restore_from_recycle_bin", true)
+	resourceData.Set("is_disabled", false)
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	reposExtClient := azdosdkmocks.NewMockGitextClient(ctrl)
+	clients := &config.AggregatedClient{
+		GitReposClient:    reposClient,
+		GitReposExtClient: reposExtClient,
+		Ctx:               context.Background(),
+	}
+
+	reposClient.
+		EXPECT().
+		GetRecycleBinRepositories(clients.Ctx, gomock.Any()).
+		Return(&[]git.GitDeletedRepository{
+			{Id: &testRepoID, Name: testAzureGitRepository.Name, Project: testAzureGitRepository.Project},
+		}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		RestoreRepositoryFromRecycleBin(clients.Ctx, gomock.Any()).
+		Return(&git.GitRepository{Id: &testRepoID, Name: testAzureGitRepository.Name}, nil).
+		Times(1)
+	reposExtClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&gitext.GitRepository{GitRepository: testAzureGitRepository, IsDisabled: converter.Bool(true)}, nil).
+		Times(1)
+
+	expectedArgs := gitext.UpdateRepositoryIsDisabledArgs{
+		IsDisabled:   converter.Bool(false),
+		RepositoryId: &testRepoID,
+		Project:      converter.String(testRepoProjectID.String()),
+	}
+	reposExtClient.
+		EXPECT().
+		UpdateRepositoryIsDisabled(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRepositoryIsDisabled() Failed")).
+		Times(1)
+
+	err := resourceAzureGitRepositoryCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRepositoryIsDisabled() Failed")
+}
+
+// verifies that a repository is disabled after all other updates are applied
+func TestAzureGitRepo_Update_DisablesRepositoryAfterUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the change of the status has to be part of the diff, a plain Set() is not reported by HasChange()
+	resourceData := schema.TestResourceDataRaw(t, resourceAzureGitRepository().Schema, map[string]interface{}{
+		"is_disabled": true,
+	})
+	flattenAzureGitRepository(resourceData, &testAzureGitRepository)
+	configureCleanInitialization(resourceData)
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	reposExtClient := azdosdkmocks.NewMockGitextClient(ctrl)
+	clients := &config.AggregatedClient{
+		GitReposClient:    reposClient,
+		GitReposExtClient: reposExtClient,
+		Ctx:               context.Background(),
+	}
+
+	updateCall := reposClient.
+		EXPECT().
+		UpdateRepository(clients.Ctx, gomock.Any()).
+		Return(&testAzureGitRepository, nil).
+		Times(1)
+
+	expectedArgs := gitext.UpdateRepositoryIsDisabledArgs{
+		IsDisabled:   converter.Bool(true),
+		RepositoryId: &testRepoID,
+		Project:      converter.String(testRepoProjectID.String()),
+	}
+	reposExtClient.
+		EXPECT().
+		UpdateRepositoryIsDisabled(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRepositoryIsDisabled() Failed")).
+		After(updateCall).
+		Times(1)
+
+	err := resourceAzureGitRepositoryUpdate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRepositoryIsDisabled() Failed")
+}
+
+// verifies that a repository which stays disabled is enabled for the time it takes to rename it
+func TestAzureGitRepo_Update_EnablesDisabledRepositoryForRename(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the rename has to be part of the diff while the status is unchanged, a plain Set() is not reported by HasChange()
+	state := &terraform.InstanceState{
+		ID: testRepoID.String(),
+		Attributes: map[string]string{
+			"id":          testRepoID.String(),
+			"name":        "RepoName",
+			"project_id":  testRepoProjectID.String(),
+			"is_disabled": "true",
+		},
+	}
+	resourceData, err := schema.InternalMap(resourceAzureGitRepository().Schema).Data(state, &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"name": {Old: "RepoName", New: "NewRepoName"},
+		},
+	})
+	require.Nil(t, err)
+	configureCleanInitialization(resourceData)
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	reposExtClient := azdosdkmocks.NewMockGitextClient(ctrl)
+	clients := &config.AggregatedClient{
+		GitReposClient:    reposClient,
+		GitReposExtClient: reposExtClient,
+		Ctx:               context.Background(),
+	}
+
+	renamedRepository := testAzureGitRepository
+	renamedRepository.Name = converter.String("NewRepoName")
+	gomock.InOrder(
+		reposExtClient.
+			EXPECT().
+			UpdateRepositoryIsDisabled(clients.Ctx, gitext.UpdateRepositoryIsDisabledArgs{
+				IsDisabled:   converter.Bool(false),
+				RepositoryId: &testRepoID,
+				Project:      converter.String(testRepoProjectID.String()),
+			}).
+			Return(nil, nil).
+			Times(1),
+		reposClient.
+			EXPECT().
+			UpdateRepository(clients.Ctx, gomock.Any()).
+			Return(&renamedRepository, nil).
+			Times(1),
+		reposExtClient.
+			EXPECT().
+			UpdateRepositoryIsDisabled(clients.Ctx, gitext.UpdateRepositoryIsDisabledArgs{
+				IsDisabled:   converter.Bool(true),
+				RepositoryId: &testRepoID,
+				Project:      converter.String(testRepoProjectID.String()),
+			}).
+			Return(nil, errors.New("UpdateRepositoryIsDisabled() Failed")).
+			Times(1),
+	)
+
+	err = resourceAzureGitRepositoryUpdate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRepositoryIsDisabled() Failed")
+	require.Equal(t, "NewRepoName", resourceData.Get("name"))
+}
+
 /**
  * Begin acceptance tests
  */
@@ -0,0 +1,120 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// the ID of the "Required reviewers" policy type
+var autoReviewersPolicyTypeID = uuid.MustParse("fd2167ab-b0be-447a-8ec8-39368250530e")
+
+// the settings of a required reviewers policy as they are stored in the policy configuration
+type autoReviewersPolicySettings struct {
+	RequiredReviewerIds  []string `json:"requiredReviewerIds"`
+	MinimumApproverCount int      `json:"minimumApproverCount"`
+	CreatorVoteCounts    bool     `json:"creatorVoteCounts"`
+	Message              string   `json:"message"`
+}
+
+func resourceBranchPolicyAutoReviewers() *schema.Resource {
+	r := crud.GenBaseBranchPolicyResource(flattenBranchPolicyAutoReviewers, expandBranchPolicyAutoReviewers)
+
+	settingsSchema := crud.GetSettingsSchema(r)
+	settingsSchema["reviewer_descriptors"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validate.NoEmptyStrings,
+		},
+		Set: schema.HashString,
+	}
+	settingsSchema["minimum_approver_count"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	settingsSchema["submitter_can_vote"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["message"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	settingsSchema["filename_patterns"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validate.NoEmptyStrings,
+		},
+	}
+
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyAutoReviewers(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseExpansion(d, autoReviewersPolicyTypeID)
+
+	descriptors := d.Get("settings.0.reviewer_descriptors").(*schema.Set).List()
+	reviewerIDs := make([]string, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		reviewerID, err := crud.GetIdentityStorageKey(clients, descriptor.(string))
+		if err != nil {
+			return nil, nil, err
+		}
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	settings["requiredReviewerIds"] = reviewerIDs
+	settings["minimumApproverCount"] = d.Get("settings.0.minimum_approver_count").(int)
+	settings["creatorVoteCounts"] = d.Get("settings.0.submitter_can_vote").(bool)
+	settings["message"] = d.Get("settings.0.message").(string)
+
+	crud.ExpandFilenamePatterns(d, settings)
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyAutoReviewers(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	var policySettings autoReviewersPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	descriptors := make([]interface{}, 0, len(policySettings.RequiredReviewerIds))
+	for _, reviewerID := range policySettings.RequiredReviewerIds {
+		descriptor, err := crud.GetIdentityDescriptor(clients, reviewerID)
+		if err != nil {
+			return err
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+
+	settings["reviewer_descriptors"] = schema.NewSet(schema.HashString, descriptors)
+	settings["minimum_approver_count"] = policySettings.MinimumApproverCount
+	settings["submitter_can_vote"] = policySettings.CreatorVoteCounts
+	settings["message"] = policySettings.Message
+	if err := crud.FlattenFilenamePatterns(policyConfig, settings); err != nil {
+		return err
+	}
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
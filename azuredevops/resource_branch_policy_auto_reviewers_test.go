@@ -0,0 +1,191 @@
+// +build all core resource_branch_policy_auto_reviewers
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testAutoReviewersPolicyProjectID = uuid.New().String()
+var testAutoReviewerStorageKey = uuid.New()
+var testAutoReviewerDescriptor = "vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5"
+
+var testAutoReviewersPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &autoReviewersPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"requiredReviewerIds":  []string{testAutoReviewerStorageKey.String()},
+		"minimumApproverCount": 1,
+		"creatorVoteCounts":    false,
+		"message":              "Changes to the deployment scripts need a review of the operations team",
+		"filenamePatterns":     []string{"/deploy/*"},
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip translates between reviewer descriptors and storage keys
+func TestBranchPolicyAutoReviewers_ExpandFlatten_Roundtrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &config.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &testAutoReviewerStorageKey}).
+		Return(&graph.GraphDescriptorResult{Value: &testAutoReviewerDescriptor}, nil).
+		Times(1)
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &testAutoReviewerDescriptor}).
+		Return(&graph.GraphStorageKeyResult{Value: &testAutoReviewerStorageKey}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyAutoReviewers().Schema, nil)
+	err := flattenBranchPolicyAutoReviewers(resourceData, clients, &testAutoReviewersPolicy, &testAutoReviewersPolicyProjectID)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{testAutoReviewerDescriptor}, resourceData.Get("settings.0.reviewer_descriptors").(*schema.Set).List())
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyAutoReviewers(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testAutoReviewersPolicyProjectID, *projectID)
+	require.Equal(t, testAutoReviewersPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testAutoReviewersPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that a reviewer that cannot be resolved fails the creation of the policy
+func TestBranchPolicyAutoReviewers_Create_DoesNotSwallowReviewerLookupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyAutoReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id": testAutoReviewersPolicyProjectID,
+		"settings": []interface{}{
+			map[string]interface{}{
+				"reviewer_descriptors": []interface{}{testAutoReviewerDescriptor},
+				"scope": []interface{}{
+					map[string]interface{}{"repository_ref": "refs/heads/master"},
+				},
+			},
+		},
+	})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{GraphClient: graphClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetStorageKey() Failed")).
+		Times(1)
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "GetStorageKey() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestBranchPolicyAutoReviewers_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyAutoReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId("42")
+	resourceData.Set("project_id", testAutoReviewersPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		GetPolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetPolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetPolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a required reviewers policy can be created, updated and imported
+func TestAccBranchPolicyAutoReviewers_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_auto_reviewers.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_auto_reviewers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyAutoReviewersResource(projectName, gitRepoName, "Contributors", "Review required"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.reviewer_descriptors.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.message", "Review required"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyAutoReviewersResource(projectName, gitRepoName, "Build Administrators", "Review of the build team required"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "settings.0.reviewer_descriptors.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.message", "Review of the build team required"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
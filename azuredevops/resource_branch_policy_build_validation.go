@@ -0,0 +1,105 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// the ID of the "Build" policy type
+var buildValidationPolicyTypeID = uuid.MustParse("0609b952-1397-4640-95ec-e00a01b2c241")
+
+// the settings of a build validation policy as they are stored in the policy configuration
+type buildValidationPolicySettings struct {
+	BuildDefinitionID       int    `json:"buildDefinitionId"`
+	DisplayName             string `json:"displayName"`
+	ManualQueueOnly         bool   `json:"manualQueueOnly"`
+	QueueOnSourceUpdateOnly bool   `json:"queueOnSourceUpdateOnly"`
+	ValidDuration           int    `json:"validDuration"`
+}
+
+func resourceBranchPolicyBuildValidation() *schema.Resource {
+	r := crud.GenBaseBranchPolicyResource(flattenBranchPolicyBuildValidation, expandBranchPolicyBuildValidation)
+
+	settingsSchema := crud.GetSettingsSchema(r)
+	settingsSchema["build_definition_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	settingsSchema["display_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validate.NoEmptyStrings,
+	}
+	settingsSchema["valid_duration"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      720,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	settingsSchema["queue_on_source_update_only"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+	settingsSchema["manual_queue_only"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["filename_patterns"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validate.NoEmptyStrings,
+		},
+	}
+
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyBuildValidation(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseExpansion(d, buildValidationPolicyTypeID)
+
+	settings["buildDefinitionId"] = d.Get("settings.0.build_definition_id").(int)
+	settings["displayName"] = d.Get("settings.0.display_name").(string)
+	settings["validDuration"] = d.Get("settings.0.valid_duration").(int)
+	settings["queueOnSourceUpdateOnly"] = d.Get("settings.0.queue_on_source_update_only").(bool)
+	settings["manualQueueOnly"] = d.Get("settings.0.manual_queue_only").(bool)
+
+	crud.ExpandFilenamePatterns(d, settings)
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyBuildValidation(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	var policySettings buildValidationPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	settings["build_definition_id"] = policySettings.BuildDefinitionID
+	settings["display_name"] = policySettings.DisplayName
+	settings["valid_duration"] = policySettings.ValidDuration
+	settings["queue_on_source_update_only"] = policySettings.QueueOnSourceUpdateOnly
+	settings["manual_queue_only"] = policySettings.ManualQueueOnly
+	if err := crud.FlattenFilenamePatterns(policyConfig, settings); err != nil {
+		return err
+	}
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
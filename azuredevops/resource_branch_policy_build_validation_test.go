@@ -0,0 +1,177 @@
+// +build all core resource_branch_policy_build_validation
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuildValidationPolicyProjectID = uuid.New().String()
+
+var testBuildValidationPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &buildValidationPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"buildDefinitionId":       7,
+		"displayName":             "CI build",
+		"manualQueueOnly":         false,
+		"queueOnSourceUpdateOnly": true,
+		"validDuration":           720,
+		"filenamePatterns":        []string{"/src/*", "!/src/*.md"},
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestBranchPolicyBuildValidation_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyBuildValidation().Schema, nil)
+	err := flattenBranchPolicyBuildValidation(resourceData, nil, &testBuildValidationPolicy, &testBuildValidationPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyBuildValidation(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testBuildValidationPolicyProjectID, *projectID)
+	require.Equal(t, testBuildValidationPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testBuildValidationPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testBuildValidationPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that no path filter is sent if no filename patterns are configured
+func TestBranchPolicyBuildValidation_Expand_OmitsEmptyFilenamePatterns(t *testing.T) {
+	settings := map[string]interface{}{}
+	for k, v := range testBuildValidationPolicy.Settings.(map[string]interface{}) {
+		settings[k] = v
+	}
+	delete(settings, "filenamePatterns")
+	policyWithoutPatterns := testBuildValidationPolicy
+	policyWithoutPatterns.Settings = settings
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyBuildValidation().Schema, nil)
+	err := flattenBranchPolicyBuildValidation(resourceData, nil, &policyWithoutPatterns, &testBuildValidationPolicyProjectID)
+	require.Nil(t, err)
+
+	policyConfig, _, err := expandBranchPolicyBuildValidation(resourceData, nil)
+	require.Nil(t, err)
+	require.NotContains(t, policyConfig.Settings, "filenamePatterns")
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBranchPolicyBuildValidation_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyBuildValidation()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyBuildValidation(resourceData, nil, &testBuildValidationPolicy, &testBuildValidationPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestBranchPolicyBuildValidation_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyBuildValidation()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyBuildValidation(resourceData, nil, &testBuildValidationPolicy, &testBuildValidationPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		UpdatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a build validation policy can be created, updated and imported
+func TestAccBranchPolicyBuildValidation_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_build_validation.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_build_validation"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyBuildValidationResource(projectName, gitRepoName, "CI build", 720),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "settings.0.build_definition_id", "azuredevops_build_definition.build", "id"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.display_name", "CI build"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.filename_patterns.#", "2"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyBuildValidationResource(projectName, gitRepoName, "Nightly build", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "settings.0.display_name", "Nightly build"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.valid_duration", "0"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
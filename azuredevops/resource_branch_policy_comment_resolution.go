@@ -0,0 +1,34 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Comment requirements" policy type
+var commentResolutionPolicyTypeID = uuid.MustParse("c6a1889d-b943-4856-b76f-9e46bb6b0df2")
+
+// the comment resolution policy has no settings besides the scope of the policy
+func resourceBranchPolicyCommentResolution() *schema.Resource {
+	return crud.GenBaseBranchPolicyResource(flattenBranchPolicyCommentResolution, expandBranchPolicyCommentResolution)
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyCommentResolution(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, _ := crud.DoBaseExpansion(d, commentResolutionPolicyTypeID)
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyCommentResolution(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
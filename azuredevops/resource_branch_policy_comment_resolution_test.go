@@ -0,0 +1,128 @@
+// +build all core resource_branch_policy_comment_resolution
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testCommentResolutionPolicyProjectID = uuid.New().String()
+
+var testCommentResolutionPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(false),
+	Type: &policy.PolicyTypeRef{
+		Id: &commentResolutionPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": nil,
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestBranchPolicyCommentResolution_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyCommentResolution().Schema, nil)
+	err := flattenBranchPolicyCommentResolution(resourceData, nil, &testCommentResolutionPolicy, &testCommentResolutionPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyCommentResolution(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testCommentResolutionPolicyProjectID, *projectID)
+	require.Equal(t, testCommentResolutionPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testCommentResolutionPolicy.IsBlocking, policyAfterRoundTrip.IsBlocking)
+	require.Equal(t, testCommentResolutionPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testCommentResolutionPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBranchPolicyCommentResolution_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyCommentResolution()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyCommentResolution(resourceData, nil, &testCommentResolutionPolicy, &testCommentResolutionPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a comment resolution policy can be created, updated and imported
+func TestAccBranchPolicyCommentResolution_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_comment_resolution.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_comment_resolution"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_comment_resolution", true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "enabled", "true"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_comment_resolution", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,115 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Require a merge strategy" policy type
+var mergeTypesPolicyTypeID = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4916e5d171ab")
+
+// the settings of a merge strategy policy as they are stored in the policy configuration
+type mergeTypesPolicySettings struct {
+	AllowNoFastForward bool `json:"allowNoFastForward"`
+	AllowSquash        bool `json:"allowSquash"`
+	AllowRebase        bool `json:"allowRebase"`
+	AllowRebaseMerge   bool `json:"allowRebaseMerge"`
+}
+
+// the settings which allow a merge type
+var mergeTypesSettingKeys = []string{
+	"settings.0.allow_basic_no_fast_forward",
+	"settings.0.allow_squash",
+	"settings.0.allow_rebase_and_fast_forward",
+	"settings.0.allow_rebase_with_merge",
+}
+
+func resourceBranchPolicyMergeTypes() *schema.Resource {
+	r := crud.GenBaseBranchPolicyResource(flattenBranchPolicyMergeTypes, expandBranchPolicyMergeTypes)
+	r.CustomizeDiff = customizeBranchPolicyMergeTypesDiff
+
+	settingsSchema := crud.GetSettingsSchema(r)
+	settingsSchema["allow_basic_no_fast_forward"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["allow_squash"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["allow_rebase_and_fast_forward"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["allow_rebase_with_merge"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+
+	return r
+}
+
+// a pull request could not be completed at all if no merge type is allowed, so this is rejected at plan time
+func customizeBranchPolicyMergeTypesDiff(d *schema.ResourceDiff, m interface{}) error {
+	for _, key := range mergeTypesSettingKeys {
+		if !d.NewValueKnown(key) || d.Get(key).(bool) {
+			return nil
+		}
+	}
+	return fmt.Errorf("At least one merge type has to be allowed")
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyMergeTypes(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseExpansion(d, mergeTypesPolicyTypeID)
+
+	policySettings := mergeTypesPolicySettings{
+		AllowNoFastForward: d.Get("settings.0.allow_basic_no_fast_forward").(bool),
+		AllowSquash:        d.Get("settings.0.allow_squash").(bool),
+		AllowRebase:        d.Get("settings.0.allow_rebase_and_fast_forward").(bool),
+		AllowRebaseMerge:   d.Get("settings.0.allow_rebase_with_merge").(bool),
+	}
+
+	// a pull request could not be completed at all if no merge type is allowed. This is already checked at plan time,
+	// but the values are not necessarily known at that point
+	if !policySettings.AllowNoFastForward && !policySettings.AllowSquash && !policySettings.AllowRebase && !policySettings.AllowRebaseMerge {
+		return nil, nil, fmt.Errorf("At least one merge type has to be allowed")
+	}
+
+	settings["allowNoFastForward"] = policySettings.AllowNoFastForward
+	settings["allowSquash"] = policySettings.AllowSquash
+	settings["allowRebase"] = policySettings.AllowRebase
+	settings["allowRebaseMerge"] = policySettings.AllowRebaseMerge
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyMergeTypes(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	var policySettings mergeTypesPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	settings["allow_basic_no_fast_forward"] = policySettings.AllowNoFastForward
+	settings["allow_squash"] = policySettings.AllowSquash
+	settings["allow_rebase_and_fast_forward"] = policySettings.AllowRebase
+	settings["allow_rebase_with_merge"] = policySettings.AllowRebaseMerge
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
@@ -0,0 +1,213 @@
+// +build all core resource_branch_policy_merge_types
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testMergeTypesPolicyProjectID = uuid.New().String()
+
+var testMergeTypesPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &mergeTypesPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"allowNoFastForward": false,
+		"allowSquash":        true,
+		"allowRebase":        false,
+		"allowRebaseMerge":   true,
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": nil,
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestBranchPolicyMergeTypes_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyMergeTypes().Schema, nil)
+	err := flattenBranchPolicyMergeTypes(resourceData, nil, &testMergeTypesPolicy, &testMergeTypesPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyMergeTypes(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testMergeTypesPolicyProjectID, *projectID)
+	require.Equal(t, testMergeTypesPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testMergeTypesPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that a policy which does not allow any merge type is rejected before it is sent to AzDO
+func TestBranchPolicyMergeTypes_Create_RequiresAtLeastOneMergeType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMergeTypes()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id": testMergeTypesPolicyProjectID,
+		"settings": []interface{}{
+			map[string]interface{}{
+				"scope": []interface{}{
+					map[string]interface{}{"repository_ref": "refs/heads/master"},
+				},
+			},
+		},
+	})
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "At least one merge type has to be allowed")
+}
+
+// verifies that a policy which does not allow any merge type is rejected at plan time
+func TestBranchPolicyMergeTypes_Diff_RequiresAtLeastOneMergeType(t *testing.T) {
+	r := resourceBranchPolicyMergeTypes()
+	genConfig := func(allowSquash bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id": testMergeTypesPolicyProjectID,
+			"settings": []interface{}{
+				map[string]interface{}{
+					"allow_squash": allowSquash,
+					"scope": []interface{}{
+						map[string]interface{}{"repository_ref": "refs/heads/master"},
+					},
+				},
+			},
+		})
+	}
+	clients := &config.AggregatedClient{Ctx: context.Background()}
+
+	_, err := r.Diff(nil, genConfig(false), clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "At least one merge type has to be allowed")
+
+	_, err = r.Diff(nil, genConfig(true), clients)
+	require.Nil(t, err)
+}
+
+// verifies that the policy is created with the ID of the "Require a merge strategy" policy type
+func TestBranchPolicyMergeTypes_Create_UsesMergeStrategyPolicyType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMergeTypes()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMergeTypes(resourceData, nil, &testMergeTypesPolicy, &testMergeTypesPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args policy.CreatePolicyConfigurationArgs) (*policy.PolicyConfiguration, error) {
+			require.Equal(t, "fa4e907d-c16b-4a4c-9dfa-4916e5d171ab", args.Configuration.Type.Id.String())
+			return nil, errors.New("CreatePolicyConfiguration() Failed")
+		}).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBranchPolicyMergeTypes_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMergeTypes()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMergeTypes(resourceData, nil, &testMergeTypesPolicy, &testMergeTypesPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a merge strategy policy can be created, updated and imported
+func TestAccBranchPolicyMergeTypes_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_merge_types.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_merge_types"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_merge_types", true, "allow_squash = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.allow_squash", "true"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.allow_rebase_with_merge", "false"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_merge_types", true, "allow_rebase_with_merge = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "settings.0.allow_squash", "false"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.allow_rebase_with_merge", "true"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
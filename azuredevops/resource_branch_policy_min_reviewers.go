@@ -0,0 +1,82 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Minimum number of reviewers" policy type
+var minReviewersPolicyTypeID = uuid.MustParse("fa4e907d-c16b-4a4c-9dfa-4906e5d171dd")
+
+// the settings of a minimum reviewer policy as they are stored in the policy configuration
+type minReviewersPolicySettings struct {
+	MinimumApproverCount int  `json:"minimumApproverCount"`
+	CreatorVoteCounts    bool `json:"creatorVoteCounts"`
+	ResetOnSourcePush    bool `json:"resetOnSourcePush"`
+	AllowDownvotes       bool `json:"allowDownvotes"`
+}
+
+func resourceBranchPolicyMinReviewers() *schema.Resource {
+	r := crud.GenBaseBranchPolicyResource(flattenBranchPolicyMinReviewers, expandBranchPolicyMinReviewers)
+
+	settingsSchema := crud.GetSettingsSchema(r)
+	settingsSchema["reviewer_count"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	settingsSchema["submitter_can_vote"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["reset_on_source_push"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["allow_completion_with_rejects_or_waits"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyMinReviewers(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseExpansion(d, minReviewersPolicyTypeID)
+
+	settings["minimumApproverCount"] = d.Get("settings.0.reviewer_count").(int)
+	settings["creatorVoteCounts"] = d.Get("settings.0.submitter_can_vote").(bool)
+	settings["resetOnSourcePush"] = d.Get("settings.0.reset_on_source_push").(bool)
+	settings["allowDownvotes"] = d.Get("settings.0.allow_completion_with_rejects_or_waits").(bool)
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyMinReviewers(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	var policySettings minReviewersPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	settings["reviewer_count"] = policySettings.MinimumApproverCount
+	settings["submitter_can_vote"] = policySettings.CreatorVoteCounts
+	settings["reset_on_source_push"] = policySettings.ResetOnSourcePush
+	settings["allow_completion_with_rejects_or_waits"] = policySettings.AllowDownvotes
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
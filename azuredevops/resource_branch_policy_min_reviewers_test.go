@@ -0,0 +1,240 @@
+// +build all core resource_branch_policy_min_reviewers
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testMinReviewersPolicyProjectID = uuid.New().String()
+var testMinReviewersPolicyRepoID = uuid.New().String()
+
+var testMinReviewersPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(false),
+	Type: &policy.PolicyTypeRef{
+		Id: &minReviewersPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"minimumApproverCount": 2,
+		"creatorVoteCounts":    true,
+		"resetOnSourcePush":    false,
+		"allowDownvotes":       true,
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": testMinReviewersPolicyRepoID,
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+			map[string]interface{}{
+				"repositoryId": nil,
+				"refName":      "refs/heads/releases/",
+				"matchKind":    "Prefix",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestBranchPolicyMinReviewers_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyMinReviewers().Schema, nil)
+	err := flattenBranchPolicyMinReviewers(resourceData, nil, &testMinReviewersPolicy, &testMinReviewersPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyMinReviewers(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testMinReviewersPolicyProjectID, *projectID)
+	require.Equal(t, testMinReviewersPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testMinReviewersPolicy.IsEnabled, policyAfterRoundTrip.IsEnabled)
+	require.Equal(t, testMinReviewersPolicy.IsBlocking, policyAfterRoundTrip.IsBlocking)
+	require.Equal(t, testMinReviewersPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testMinReviewersPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBranchPolicyMinReviewers_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMinReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMinReviewers(resourceData, nil, &testMinReviewersPolicy, &testMinReviewersPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestBranchPolicyMinReviewers_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMinReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMinReviewers(resourceData, nil, &testMinReviewersPolicy, &testMinReviewersPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	expectedArgs := policy.GetPolicyConfigurationArgs{
+		Project:         &testMinReviewersPolicyProjectID,
+		ConfigurationId: testMinReviewersPolicy.Id,
+	}
+	policyClient.
+		EXPECT().
+		GetPolicyConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetPolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetPolicyConfiguration() Failed")
+}
+
+// verifies that the resource is removed from the state if the policy no longer exists
+func TestBranchPolicyMinReviewers_Read_ClearsIDIfPolicyDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMinReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMinReviewers(resourceData, nil, &testMinReviewersPolicy, &testMinReviewersPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		GetPolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestBranchPolicyMinReviewers_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMinReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMinReviewers(resourceData, nil, &testMinReviewersPolicy, &testMinReviewersPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		UpdatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdatePolicyConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestBranchPolicyMinReviewers_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyMinReviewers()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyMinReviewers(resourceData, nil, &testMinReviewersPolicy, &testMinReviewersPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	expectedArgs := policy.DeletePolicyConfigurationArgs{
+		Project:         &testMinReviewersPolicyProjectID,
+		ConfigurationId: testMinReviewersPolicy.Id,
+	}
+	policyClient.
+		EXPECT().
+		DeletePolicyConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeletePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeletePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a minimum reviewer policy can be created, updated and imported
+func TestAccBranchPolicyMinReviewers_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_min_reviewers.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_min_reviewers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyMinReviewersResource(projectName, gitRepoName, 1, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "enabled", "true"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.reviewer_count", "1"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyMinReviewersResource(projectName, gitRepoName, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "enabled", "false"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.reviewer_count", "2"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,144 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// the ID of the "Status" policy type
+var statusCheckPolicyTypeID = uuid.MustParse("cbdc66da-9728-4af8-aada-9a5a32e4a226")
+
+// The applicability of a status check policy. By default the policy applies to every iteration of a pull
+// request, while a conditional policy only applies once a status has been posted to the pull request.
+const (
+	statusCheckApplicabilityDefault     = "default"
+	statusCheckApplicabilityConditional = "conditional"
+)
+
+// the value of the policy setting for a conditional status check, the setting is not set for the default
+const statusCheckPolicyApplicabilityConditional = 1
+
+// the settings of a status check policy as they are stored in the policy configuration
+type statusCheckPolicySettings struct {
+	StatusName               string `json:"statusName"`
+	StatusGenre              string `json:"statusGenre"`
+	AuthorID                 string `json:"authorId"`
+	InvalidateOnSourceUpdate bool   `json:"invalidateOnSourceUpdate"`
+	PolicyApplicability      *int   `json:"policyApplicability"`
+	DefaultDisplayName       string `json:"defaultDisplayName"`
+}
+
+func resourceBranchPolicyStatusCheck() *schema.Resource {
+	r := crud.GenBaseBranchPolicyResource(flattenBranchPolicyStatusCheck, expandBranchPolicyStatusCheck)
+
+	settingsSchema := crud.GetSettingsSchema(r)
+	settingsSchema["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validate.NoEmptyStrings,
+	}
+	settingsSchema["genre"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	settingsSchema["author_descriptor"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	settingsSchema["invalidate_on_update"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	settingsSchema["applicability"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      statusCheckApplicabilityDefault,
+		ValidateFunc: validation.StringInSlice([]string{statusCheckApplicabilityDefault, statusCheckApplicabilityConditional}, false),
+	}
+	settingsSchema["display_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	settingsSchema["filename_patterns"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validate.NoEmptyStrings,
+		},
+	}
+
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyStatusCheck(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseExpansion(d, statusCheckPolicyTypeID)
+
+	settings["statusName"] = d.Get("settings.0.name").(string)
+	settings["statusGenre"] = d.Get("settings.0.genre").(string)
+	settings["invalidateOnSourceUpdate"] = d.Get("settings.0.invalidate_on_update").(bool)
+	settings["defaultDisplayName"] = d.Get("settings.0.display_name").(string)
+
+	// the status can be posted by any identity if no author is configured
+	if descriptor := d.Get("settings.0.author_descriptor").(string); descriptor != "" {
+		authorID, err := crud.GetIdentityStorageKey(clients, descriptor)
+		if err != nil {
+			return nil, nil, err
+		}
+		settings["authorId"] = authorID
+	}
+
+	if d.Get("settings.0.applicability").(string) == statusCheckApplicabilityConditional {
+		settings["policyApplicability"] = statusCheckPolicyApplicabilityConditional
+	}
+
+	crud.ExpandFilenamePatterns(d, settings)
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyStatusCheck(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	var policySettings statusCheckPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	authorDescriptor := ""
+	if policySettings.AuthorID != "" {
+		authorDescriptor, err = crud.GetIdentityDescriptor(clients, policySettings.AuthorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	applicability := statusCheckApplicabilityDefault
+	if policySettings.PolicyApplicability != nil && *policySettings.PolicyApplicability == statusCheckPolicyApplicabilityConditional {
+		applicability = statusCheckApplicabilityConditional
+	}
+
+	settings["name"] = policySettings.StatusName
+	settings["genre"] = policySettings.StatusGenre
+	settings["author_descriptor"] = authorDescriptor
+	settings["invalidate_on_update"] = policySettings.InvalidateOnSourceUpdate
+	settings["applicability"] = applicability
+	settings["display_name"] = policySettings.DefaultDisplayName
+	if err := crud.FlattenFilenamePatterns(policyConfig, settings); err != nil {
+		return err
+	}
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
@@ -0,0 +1,194 @@
+// +build all core resource_branch_policy_status_check
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testStatusCheckPolicyProjectID = uuid.New().String()
+var testStatusCheckAuthorStorageKey = uuid.New()
+var testStatusCheckAuthorDescriptor = "svc.YzM5ZjQ3NGUtY2YxYS00ZjgyLTk0ZTctMTg2ZWZlYWI5ODk4"
+
+var testStatusCheckPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &statusCheckPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"statusName":               "quality-gate",
+		"statusGenre":              "sonarqube",
+		"authorId":                 testStatusCheckAuthorStorageKey.String(),
+		"invalidateOnSourceUpdate": true,
+		"policyApplicability":      1,
+		"defaultDisplayName":       "SonarQube quality gate",
+		"filenamePatterns":         []string{"/src/*"},
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestBranchPolicyStatusCheck_ExpandFlatten_Roundtrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &config.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &testStatusCheckAuthorStorageKey}).
+		Return(&graph.GraphDescriptorResult{Value: &testStatusCheckAuthorDescriptor}, nil).
+		Times(1)
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &testStatusCheckAuthorDescriptor}).
+		Return(&graph.GraphStorageKeyResult{Value: &testStatusCheckAuthorStorageKey}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyStatusCheck().Schema, nil)
+	err := flattenBranchPolicyStatusCheck(resourceData, clients, &testStatusCheckPolicy, &testStatusCheckPolicyProjectID)
+	require.Nil(t, err)
+	require.Equal(t, statusCheckApplicabilityConditional, resourceData.Get("settings.0.applicability"))
+	require.Equal(t, testStatusCheckAuthorDescriptor, resourceData.Get("settings.0.author_descriptor"))
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyStatusCheck(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testStatusCheckPolicyProjectID, *projectID)
+	require.Equal(t, testStatusCheckPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testStatusCheckPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that a policy without author and with the default applicability does not send these settings
+func TestBranchPolicyStatusCheck_Expand_OmitsOptionalSettings(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyStatusCheck().Schema, map[string]interface{}{
+		"project_id": testStatusCheckPolicyProjectID,
+		"settings": []interface{}{
+			map[string]interface{}{
+				"name": "quality-gate",
+				"scope": []interface{}{
+					map[string]interface{}{"repository_ref": "refs/heads/master"},
+				},
+			},
+		},
+	})
+
+	policyConfig, _, err := expandBranchPolicyStatusCheck(resourceData, nil)
+	require.Nil(t, err)
+	require.NotContains(t, policyConfig.Settings, "authorId")
+	require.NotContains(t, policyConfig.Settings, "policyApplicability")
+	require.NotContains(t, policyConfig.Settings, "filenamePatterns")
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBranchPolicyStatusCheck_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyStatusCheck()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id": testStatusCheckPolicyProjectID,
+		"settings": []interface{}{
+			map[string]interface{}{
+				"name": "quality-gate",
+				"scope": []interface{}{
+					map[string]interface{}{"repository_ref": "refs/heads/master"},
+				},
+			},
+		},
+	})
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a status check policy can be created, updated and imported
+func TestAccBranchPolicyStatusCheck_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_status_check.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_status_check"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_status_check", true, `
+		name  = "quality-gate"
+		genre = "sonarqube"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.name", "quality-gate"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.applicability", "default"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_status_check", true, `
+		name                 = "quality-gate"
+		genre                = "sonarqube"
+		applicability        = "conditional"
+		invalidate_on_update = true
+		filename_patterns    = ["/src/*"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "settings.0.applicability", "conditional"),
+					resource.TestCheckResourceAttr(tfNode, "settings.0.invalidate_on_update", "true"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,34 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Work item linking" policy type
+var workItemLinkingPolicyTypeID = uuid.MustParse("40e92b44-2fe1-4dd6-b3d8-74a9c21d0c6e")
+
+// the work item linking policy has no settings besides the scope of the policy
+func resourceBranchPolicyWorkItemLinking() *schema.Resource {
+	return crud.GenBaseBranchPolicyResource(flattenBranchPolicyWorkItemLinking, expandBranchPolicyWorkItemLinking)
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandBranchPolicyWorkItemLinking(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, _ := crud.DoBaseExpansion(d, workItemLinkingPolicyTypeID)
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenBranchPolicyWorkItemLinking(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	settings, err := crud.DoBaseFlattening(d, policyConfig, projectID)
+	if err != nil {
+		return err
+	}
+
+	return d.Set(crud.SchemaSettings, []interface{}{settings})
+}
@@ -0,0 +1,128 @@
+// +build all core resource_branch_policy_work_item_linking
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testWorkItemLinkingPolicyProjectID = uuid.New().String()
+
+var testWorkItemLinkingPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(false),
+	Type: &policy.PolicyTypeRef{
+		Id: &workItemLinkingPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": nil,
+				"refName":      "refs/heads/master",
+				"matchKind":    "Exact",
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestBranchPolicyWorkItemLinking_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBranchPolicyWorkItemLinking().Schema, nil)
+	err := flattenBranchPolicyWorkItemLinking(resourceData, nil, &testWorkItemLinkingPolicy, &testWorkItemLinkingPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandBranchPolicyWorkItemLinking(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testWorkItemLinkingPolicyProjectID, *projectID)
+	require.Equal(t, testWorkItemLinkingPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testWorkItemLinkingPolicy.IsBlocking, policyAfterRoundTrip.IsBlocking)
+	require.Equal(t, testWorkItemLinkingPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testWorkItemLinkingPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBranchPolicyWorkItemLinking_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceBranchPolicyWorkItemLinking()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenBranchPolicyWorkItemLinking(resourceData, nil, &testWorkItemLinkingPolicy, &testWorkItemLinkingPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a work item linking policy can be created, updated and imported
+func TestAccBranchPolicyWorkItemLinking_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_branch_policy_work_item_linking.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_branch_policy_work_item_linking"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_work_item_linking", true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "enabled", "true"),
+				),
+			},
+			{
+				Config: testhelper.TestAccBranchPolicyResource(projectName, gitRepoName, "azuredevops_branch_policy_work_item_linking", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -1,18 +1,139 @@
 package azuredevops
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/secretmemo"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tfhelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+)
+
+// the source of the settings of a build definition trigger
+const (
+	triggerSettingsSourceTypeDefinition = 1
+	triggerSettingsSourceTypeYaml       = 2
+)
+
+// the trigger models of the SDK lack the `triggerType` discriminator that the service
+// requires to tell the different kinds of triggers apart, so they are wrapped before being sent
+type buildDefinitionTrigger struct {
+	TriggerType *build.DefinitionTriggerType `json:"triggerType,omitempty"`
+}
+
+type ciTrigger struct {
+	build.ContinuousIntegrationTrigger
+	TriggerType *build.DefinitionTriggerType `json:"triggerType,omitempty"`
+}
+
+type pullRequestTrigger struct {
+	build.PullRequestTrigger
+	TriggerType *build.DefinitionTriggerType `json:"triggerType,omitempty"`
+}
+
+type scheduleTrigger struct {
+	build.ScheduleTrigger
+	TriggerType *build.DefinitionTriggerType `json:"triggerType,omitempty"`
+}
+
+type buildCompletionTrigger struct {
+	build.BuildCompletionTrigger
+	TriggerType *build.DefinitionTriggerType `json:"triggerType,omitempty"`
+}
+
+type gatedCheckInTrigger struct {
+	build.GatedCheckInTrigger
+	TriggerType *build.DefinitionTriggerType `json:"triggerType,omitempty"`
+}
+
+// the type of the classic designer process, which builds TFVC repositories as YAML pipelines do not support them
+const processTypeDesigner = 1
+
+// the process model of the SDK lacks the `type` discriminator as well
+type designerProcess struct {
+	build.DesignerProcess
+	Type *int `json:"type,omitempty"`
+}
+
+// the workspace mappings of a TFVC repository, which are stored as JSON in the `tfvcMapping` property
+type tfvcWorkspaceMappings struct {
+	Mappings []tfvcWorkspaceMapping `json:"mappings"`
+}
+
+type tfvcWorkspaceMapping struct {
+	ServerPath  string `json:"serverPath"`
+	MappingType string `json:"mappingType"`
+	LocalPath   string `json:"localPath"`
+}
+
+// the days a schedule can run on, in the order they are sent to AzDO
+var scheduleDays = []build.ScheduleDays{
+	build.ScheduleDaysValues.Monday,
+	build.ScheduleDaysValues.Tuesday,
+	build.ScheduleDaysValues.Wednesday,
+	build.ScheduleDaysValues.Thursday,
+	build.ScheduleDaysValues.Friday,
+	build.ScheduleDaysValues.Saturday,
+	build.ScheduleDaysValues.Sunday,
+}
+
+// the types of artifacts a retention rule can delete
+var retentionArtifactTypes = []string{"FilePath", "SymbolStore"}
+
+// the agent queue that is used if neither agent_pool_name nor agent_queue_id is configured
+const defaultBuildDefinitionAgentQueueName = "Hosted Ubuntu 1604"
+
+// the types of repositories a build definition can build
+const (
+	repoTypeTfsGit            = "TfsGit"
+	repoTypeGitHub            = "GitHub"
+	repoTypeGitHubEnterprise  = "GitHubEnterprise"
+	repoTypeBitbucket         = "Bitbucket"
+	repoTypeGit               = "Git"
+	repoTypeTfsVersionControl = "TfsVersionControl"
+)
+
+// the types of the workspace mappings of a TFVC repository
+const (
+	workspaceMappingTypeMap   = "map"
+	workspaceMappingTypeCloak = "cloak"
+)
+
+// the URL of a repository hosted on a public service is derived from the name of the repository.
+// Repositories of all other types except TfsGit and TfsVersionControl are configured with their URL.
+var buildRepositoryURLFormats = map[string]string{
+	repoTypeGitHub:    "https://github.com/%s.git",
+	repoTypeBitbucket: "https://bitbucket.org/%s.git",
+}
+
+// the settings of a cloned build definition that are copied from its source definition when it is created,
+// mapped to the trigger types they contain. Configured settings override the copied ones.
+var clonedBuildDefinitionSettings = map[string]*build.DefinitionTriggerType{
+	"variable":                 nil,
+	"retention_rule":           nil,
+	"ci_trigger":               &build.DefinitionTriggerTypeValues.ContinuousIntegration,
+	"pull_request_trigger":     &build.DefinitionTriggerTypeValues.PullRequest,
+	"schedule":                 &build.DefinitionTriggerTypeValues.Schedule,
+	"build_completion_trigger": &build.DefinitionTriggerTypeValues.BuildCompletion,
+	"gated_checkin_trigger":    &build.DefinitionTriggerTypeValues.GatedCheckIn,
+}
+
+// the values of the comment_required setting of a pull request trigger
+const (
+	pullRequestCommentRequiredAll            = "All"
+	pullRequestCommentRequiredNonTeamMembers = "NonTeamMembers"
 )
 
 func resourceBuildDefinition() *schema.Resource {
@@ -21,6 +142,10 @@ func resourceBuildDefinition() *schema.Resource {
 		Read:   resourceBuildDefinitionRead,
 		Update: resourceBuildDefinitionUpdate,
 		Delete: resourceBuildDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: importBuildDefinition,
+		},
+		CustomizeDiff: customizeBuildDefinitionDiff,
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
@@ -42,6 +167,18 @@ func resourceBuildDefinition() *schema.Resource {
 				Optional:     true,
 				Default:      "\\",
 				ValidateFunc: validate.FilePathOrEmpty,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return isBuildDefinitionPathEqual(old, new)
+				},
+			},
+			"source_definition_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				// the settings are only copied on creation, so a change of the source definition has no effect afterwards
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
 			},
 			"variable_groups": {
 				Type: schema.TypeSet,
@@ -52,10 +189,323 @@ func resourceBuildDefinition() *schema.Resource {
 				MinItems: 1,
 				Optional: true,
 			},
+			"variable": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      getBuildDefinitionVariableHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"value": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							Sensitive:        true,
+							DiffSuppressFunc: suppressBuildDefinitionSecretVariableChanged,
+						},
+						"value_hash": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+							Description: "A bcrypted hash of the value of a secret variable",
+						},
+						"is_secret": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"allow_override": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
 			"agent_pool_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validate.NoEmptyStrings,
+				ConflictsWith: []string{"agent_queue_id"},
+			},
+			"agent_queue_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"agent_pool_name"},
+			},
+			"queue_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(build.DefinitionQueueStatusValues.Enabled),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.DefinitionQueueStatusValues.Enabled),
+					string(build.DefinitionQueueStatusValues.Paused),
+					string(build.DefinitionQueueStatusValues.Disabled),
+				}, false),
+			},
+			"build_number_format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"job_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"job_cancel_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 60),
+			},
+			"job_authorization_scope": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "Hosted Ubuntu 1604",
+				Default:  string(build.BuildAuthorizationScopeValues.ProjectCollection),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildAuthorizationScopeValues.ProjectCollection),
+					string(build.BuildAuthorizationScopeValues.Project),
+				}, false),
+			},
+			"badge_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"demands": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+				Optional: true,
+			},
+			"ci_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"use_yaml": {
+							Type:          schema.TypeBool,
+							Optional:      true,
+							Default:       false,
+							ConflictsWith: []string{"ci_trigger.0.override"},
+						},
+						"override": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"batch": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+									"max_concurrent_builds_per_branch": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									// TFVC repositories have no branches, their builds are triggered by path filters only
+									"branch_filter": genBuildDefinitionFilterSchema(false),
+									"path_filter":   genBuildDefinitionFilterSchema(false),
+								},
+							},
+						},
+					},
+				},
+			},
+			"pull_request_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"use_yaml": {
+							Type:          schema.TypeBool,
+							Optional:      true,
+							Default:       false,
+							ConflictsWith: []string{"pull_request_trigger.0.override"},
+						},
+						"comment_required": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+							ValidateFunc: validation.StringInSlice([]string{
+								"",
+								pullRequestCommentRequiredAll,
+								pullRequestCommentRequiredNonTeamMembers,
+							}, false),
+						},
+						"forks": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:     schema.TypeBool,
+										Required: true,
+									},
+									"share_secrets": {
+										Type:     schema.TypeBool,
+										Required: true,
+									},
+								},
+							},
+						},
+						"override": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"auto_cancel": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+									"branch_filter": genBuildDefinitionFilterSchema(true),
+									"path_filter":   genBuildDefinitionFilterSchema(false),
+								},
+							},
+						},
+					},
+				},
+			},
+			"schedule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days_to_build": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+								}, false),
+							},
+						},
+						"start_hours": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"start_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 59),
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"schedule_only_with_changes": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"branch_filter": genBuildDefinitionFilterSchema(true),
+					},
+				},
+			},
+			"build_completion_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"definition_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"requires_successful_build": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"branch_filter": genBuildDefinitionFilterSchema(true),
+					},
+				},
+			},
+			"gated_checkin_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"run_continuous_integration": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"use_workspace_mappings": {
+							Type:          schema.TypeBool,
+							Optional:      true,
+							Default:       true,
+							ConflictsWith: []string{"gated_checkin_trigger.0.path_filter"},
+						},
+						"path_filter": genBuildDefinitionFilterSchema(false),
+					},
+				},
+			},
+			"retention_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branch_filter": genBuildDefinitionFilterSchema(true),
+						"days_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"minimum_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"delete_build_record": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"delete_test_results": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"artifact_types_to_delete": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(retentionArtifactTypes, false),
+							},
+						},
+					},
+				},
 			},
 			"repository": {
 				Type:     schema.TypeSet,
@@ -66,16 +516,30 @@ func resourceBuildDefinition() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"yml_path": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
+							Default:  "",
 						},
 						"repo_name": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
 						"repo_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								repoTypeGitHub,
+								repoTypeTfsGit,
+								repoTypeGitHubEnterprise,
+								repoTypeBitbucket,
+								repoTypeGit,
+								repoTypeTfsVersionControl,
+							}, false),
+						},
+						"url": {
 							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"GitHub", "TfsGit"}, false),
+							Optional:     true,
+							Default:      "",
+							ValidateFunc: validate.URLOrEmpty,
 						},
 						"branch_name": {
 							Type:     schema.TypeString,
@@ -87,6 +551,80 @@ func resourceBuildDefinition() *schema.Resource {
 							Optional: true,
 							Default:  "",
 						},
+						"workspace_mapping": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"server_path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateTfvcServerPath,
+									},
+									"mapping_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  workspaceMappingTypeMap,
+										ValidateFunc: validation.StringInSlice([]string{
+											workspaceMappingTypeMap,
+											workspaceMappingTypeCloak,
+										}, false),
+									},
+									"local_path": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "",
+										DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+											return isBuildDefinitionPathEqual(old, new)
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// server paths of TFVC are rooted at `$/`, e.g. `$/project/main`
+func validateTfvcServerPath(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if !strings.HasPrefix(v, "$/") {
+		return nil, []error{fmt.Errorf("%q must be a TFVC server path starting with $/, got: %s", k, v)}
+	}
+	return nil, nil
+}
+
+// branch and path filters are sent to AzDO as a single list in which includes are prefixed
+// with `+` and excludes with `-`
+func genBuildDefinitionFilterSchema(required bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: !required,
+		Required: required,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"include": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validate.NoEmptyStrings,
+					},
+				},
+				"exclude": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validate.NoEmptyStrings,
 					},
 				},
 			},
@@ -94,6 +632,34 @@ func resourceBuildDefinition() *schema.Resource {
 	}
 }
 
+// the root folder of the build definitions is `\`
+func expandBuildDefinitionPath(path string) string {
+	if path == "" {
+		return "\\"
+	}
+	return path
+}
+
+func isBuildDefinitionPathEqual(a string, b string) bool {
+	return strings.EqualFold(strings.Trim(a, "\\"), strings.Trim(b, "\\"))
+}
+
+// variables are identified by their name, so that the hash of a secret value can be found
+// again for the same variable when the other attributes of the variable change
+func getBuildDefinitionVariableHash(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["name"].(string))
+}
+
+// the value of a secret variable is never returned by AzDO. Changes to it are detected by
+// comparing the configured value with the hash stored in the state, see tfhelper.DiffFuncSupressSecretChanged.
+func suppressBuildDefinitionSecretVariableChanged(k, old, new string, d *schema.ResourceData) bool {
+	isSecretKey := strings.TrimSuffix(k, "value") + "is_secret"
+	if !d.Get(isSecretKey).(bool) {
+		return false
+	}
+	return tfhelper.DiffFuncSupressSecretChanged(k, old, new, d)
+}
+
 func resourceBuildDefinitionCreate(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*config.AggregatedClient)
 	buildDefinition, projectID, err := expandBuildDefinition(d)
@@ -101,24 +667,62 @@ func resourceBuildDefinitionCreate(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Error creating resource Build Definition: %+v", err)
 	}
 
+	if err := resolveBuildDefinitionAgentQueue(clients, buildDefinition, projectID); err != nil {
+		return fmt.Errorf("Error creating resource Build Definition: %+v", err)
+	}
+
+	if sourceDefinitionID, ok := d.GetOk("source_definition_id"); ok {
+		if err := cloneBuildDefinitionSettings(clients, d, buildDefinition, projectID, sourceDefinitionID.(int)); err != nil {
+			return fmt.Errorf("Error creating resource Build Definition: %+v", err)
+		}
+	}
+
 	createdBuildDefinition, err := createBuildDefinition(clients, buildDefinition, projectID)
 	if err != nil {
 		return fmt.Errorf("Error creating resource Build Definition: %+v", err)
 	}
 
-	flattenBuildDefinition(d, createdBuildDefinition, projectID)
+	if err := flattenBuildDefinition(d, createdBuildDefinition, projectID); err != nil {
+		return err
+	}
 	return resourceBuildDefinitionRead(d, m)
 }
 
-func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.BuildDefinition, projectID string) {
+func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.BuildDefinition, projectID string) error {
 	d.SetId(strconv.Itoa(*buildDefinition.Id))
 
 	d.Set("project_id", projectID)
 	d.Set("name", *buildDefinition.Name)
+	// AzDO normalizes the path, e.g. an empty path is stored as `\`, so the path is kept as configured if it is equivalent
+	if !isBuildDefinitionPathEqual(d.Get("path").(string), converter.ToString(buildDefinition.Path, "")) {
+		d.Set("path", converter.ToString(buildDefinition.Path, ""))
+	}
 	d.Set("repository", flattenRepository(buildDefinition))
-	d.Set("agent_pool_name", *buildDefinition.Queue.Pool.Name)
+	if buildDefinition.Queue != nil {
+		d.Set("agent_pool_name", converter.ToString(buildDefinition.Queue.Name, ""))
+		d.Set("agent_queue_id", converter.ToInt(buildDefinition.Queue.Id, 0))
+	}
+	if buildDefinition.QueueStatus != nil {
+		d.Set("queue_status", string(*buildDefinition.QueueStatus))
+	}
+	d.Set("build_number_format", converter.ToString(buildDefinition.BuildNumberFormat, ""))
+	d.Set("job_timeout_in_minutes", converter.ToInt(buildDefinition.JobTimeoutInMinutes, 0))
+	d.Set("job_cancel_timeout_in_minutes", converter.ToInt(buildDefinition.JobCancelTimeoutInMinutes, 0))
+	if buildDefinition.JobAuthorizationScope != nil {
+		d.Set("job_authorization_scope", string(*buildDefinition.JobAuthorizationScope))
+	}
+	d.Set("badge_enabled", converter.ToBool(buildDefinition.BadgeEnabled, false))
+	if isBuildDefinitionSettingManaged(d, "demands") {
+		d.Set("demands", flattenBuildDefinitionDemands(buildDefinition))
+	}
+	if isBuildDefinitionSettingManaged(d, "retention_rule") {
+		d.Set("retention_rule", flattenBuildDefinitionRetentionRules(buildDefinition))
+	}
 
 	d.Set("variable_groups", flattenVariableGroups(buildDefinition))
+	if isBuildDefinitionSettingManaged(d, "variable") {
+		d.Set("variable", flattenBuildDefinitionVariables(d, buildDefinition))
+	}
 
 	revision := 0
 	if buildDefinition.Revision != nil {
@@ -126,78 +730,405 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 	}
 
 	d.Set("revision", revision)
+
+	return flattenBuildDefinitionTriggers(d, buildDefinition)
 }
 
-func flattenVariableGroups(buildDefinition *build.BuildDefinition) []int {
-	if buildDefinition.VariableGroups == nil {
-		return nil
-	}
+func flattenBuildDefinitionTriggers(d *schema.ResourceData, buildDefinition *build.BuildDefinition) error {
+	ciTriggers := []interface{}{}
+	pullRequestTriggers := []interface{}{}
+	schedules := []interface{}{}
+	buildCompletionTriggers := []interface{}{}
+	gatedCheckInTriggers := []interface{}{}
 
-	variableGroups := make([]int, len(*buildDefinition.VariableGroups))
+	if buildDefinition.Triggers != nil {
+		for _, rawTrigger := range *buildDefinition.Triggers {
+			var trigger buildDefinitionTrigger
+			if err := decodeBuildDefinitionTrigger(rawTrigger, &trigger); err != nil {
+				return err
+			}
+			if trigger.TriggerType == nil {
+				continue
+			}
 
-	for i, variableGroup := range *buildDefinition.VariableGroups {
-		variableGroups[i] = *variableGroup.Id
+			switch *trigger.TriggerType {
+			case build.DefinitionTriggerTypeValues.ContinuousIntegration:
+				var ci ciTrigger
+				if err := decodeBuildDefinitionTrigger(rawTrigger, &ci); err != nil {
+					return err
+				}
+				ciTriggers = append(ciTriggers, flattenCiTrigger(&ci))
+			case build.DefinitionTriggerTypeValues.PullRequest:
+				var pr pullRequestTrigger
+				if err := decodeBuildDefinitionTrigger(rawTrigger, &pr); err != nil {
+					return err
+				}
+				pullRequestTriggers = append(pullRequestTriggers, flattenPullRequestTrigger(&pr))
+			case build.DefinitionTriggerTypeValues.Schedule:
+				var schedule scheduleTrigger
+				if err := decodeBuildDefinitionTrigger(rawTrigger, &schedule); err != nil {
+					return err
+				}
+				schedules = append(schedules, flattenScheduleTrigger(&schedule)...)
+			case build.DefinitionTriggerTypeValues.BuildCompletion:
+				var completion buildCompletionTrigger
+				if err := decodeBuildDefinitionTrigger(rawTrigger, &completion); err != nil {
+					return err
+				}
+				buildCompletionTriggers = append(buildCompletionTriggers, flattenBuildCompletionTrigger(&completion))
+			case build.DefinitionTriggerTypeValues.GatedCheckIn:
+				var gated gatedCheckInTrigger
+				if err := decodeBuildDefinitionTrigger(rawTrigger, &gated); err != nil {
+					return err
+				}
+				gatedCheckInTriggers = append(gatedCheckInTriggers, flattenGatedCheckInTrigger(&gated))
+			}
+		}
 	}
 
-	return variableGroups
+	for key, triggers := range map[string][]interface{}{
+		"ci_trigger":               ciTriggers,
+		"pull_request_trigger":     pullRequestTriggers,
+		"schedule":                 schedules,
+		"build_completion_trigger": buildCompletionTriggers,
+		"gated_checkin_trigger":    gatedCheckInTriggers,
+	} {
+		if !isBuildDefinitionTriggerManaged(d, key) {
+			continue
+		}
+		if err := d.Set(key, triggers); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func createBuildDefinition(clients *config.AggregatedClient, buildDefinition *build.BuildDefinition, project string) (*build.BuildDefinition, error) {
-	createdBuild, err := clients.BuildClient.CreateDefinition(clients.Ctx, build.CreateDefinitionArgs{
-		Definition: buildDefinition,
-		Project:    &project,
-	})
-
-	return createdBuild, err
+// the triggers are untyped in the SDK model. Depending on whether they were built by the provider or
+// read from the service they are either one of the wrapper types above or a generic map.
+func decodeBuildDefinitionTrigger(rawTrigger interface{}, v interface{}) error {
+	raw, err := json.Marshal(rawTrigger)
+	if err != nil {
+		return fmt.Errorf("Error reading the triggers of the build definition: %+v", err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("Error reading the triggers of the build definition: %+v", err)
+	}
+	return nil
 }
 
-func resourceBuildDefinitionRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(*config.AggregatedClient)
-	projectID, buildDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+func flattenCiTrigger(trigger *ciTrigger) interface{} {
+	if converter.ToInt(trigger.SettingsSourceType, triggerSettingsSourceTypeDefinition) == triggerSettingsSourceTypeYaml {
+		return map[string]interface{}{
+			"use_yaml": true,
+		}
+	}
 
-	if err != nil {
-		return err
+	return map[string]interface{}{
+		"use_yaml": false,
+		"override": []interface{}{map[string]interface{}{
+			"batch":                            converter.ToBool(trigger.BatchChanges, false),
+			"max_concurrent_builds_per_branch": converter.ToInt(trigger.MaxConcurrentBuildsPerBranch, 1),
+			"branch_filter":                    flattenBuildDefinitionFilters(trigger.BranchFilters),
+			"path_filter":                      flattenBuildDefinitionFilters(trigger.PathFilters),
+		}},
 	}
+}
 
-	buildDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
-		Project:      &projectID,
-		DefinitionId: &buildDefinitionID,
-	})
+func flattenPullRequestTrigger(trigger *pullRequestTrigger) interface{} {
+	commentRequired := ""
+	if converter.ToBool(trigger.IsCommentRequiredForPullRequest, false) {
+		commentRequired = pullRequestCommentRequiredAll
+		if converter.ToBool(trigger.RequireCommentsForNonTeamMembersOnly, false) {
+			commentRequired = pullRequestCommentRequiredNonTeamMembers
+		}
+	}
 
-	if err != nil {
-		return err
+	forks := map[string]interface{}{
+		"enabled":       false,
+		"share_secrets": false,
+	}
+	if trigger.Forks != nil {
+		forks["enabled"] = converter.ToBool(trigger.Forks.Enabled, false)
+		forks["share_secrets"] = converter.ToBool(trigger.Forks.AllowSecrets, false)
 	}
 
-	flattenBuildDefinition(d, buildDefinition, projectID)
-	return nil
+	result := map[string]interface{}{
+		"use_yaml":         false,
+		"comment_required": commentRequired,
+		"forks":            []interface{}{forks},
+	}
+
+	if converter.ToInt(trigger.SettingsSourceType, triggerSettingsSourceTypeDefinition) == triggerSettingsSourceTypeYaml {
+		result["use_yaml"] = true
+	} else {
+		result["override"] = []interface{}{map[string]interface{}{
+			"auto_cancel":   converter.ToBool(trigger.AutoCancel, false),
+			"branch_filter": flattenBuildDefinitionFilters(trigger.BranchFilters),
+			"path_filter":   flattenBuildDefinitionFilters(trigger.PathFilters),
+		}}
+	}
+	return result
 }
 
-func resourceBuildDefinitionDelete(d *schema.ResourceData, m interface{}) error {
-	if d.Id() == "" {
-		return nil
+func flattenScheduleTrigger(trigger *scheduleTrigger) []interface{} {
+	if trigger.Schedules == nil {
+		return []interface{}{}
 	}
 
-	clients := m.(*config.AggregatedClient)
-	projectID, buildDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
-	if err != nil {
-		return err
+	results := make([]interface{}, 0, len(*trigger.Schedules))
+	for _, schedule := range *trigger.Schedules {
+		results = append(results, map[string]interface{}{
+			"days_to_build":              flattenScheduleDays(schedule.DaysToBuild),
+			"start_hours":                converter.ToInt(schedule.StartHours, 0),
+			"start_minutes":              converter.ToInt(schedule.StartMinutes, 0),
+			"time_zone":                  converter.ToString(schedule.TimeZoneId, "UTC"),
+			"schedule_only_with_changes": converter.ToBool(schedule.ScheduleOnlyWithChanges, false),
+			"branch_filter":              flattenBuildDefinitionFilters(schedule.BranchFilters),
+		})
 	}
+	return results
+}
 
-	err = clients.BuildClient.DeleteDefinition(m.(*config.AggregatedClient).Ctx, build.DeleteDefinitionArgs{
-		Project:      &projectID,
-		DefinitionId: &buildDefinitionID,
-	})
+func flattenBuildCompletionTrigger(trigger *buildCompletionTrigger) interface{} {
+	definitionID := 0
+	if trigger.Definition != nil {
+		definitionID = converter.ToInt(trigger.Definition.Id, 0)
+	}
 
-	return err
+	return map[string]interface{}{
+		"definition_id":             definitionID,
+		"requires_successful_build": converter.ToBool(trigger.RequiresSuccessfulBuild, false),
+		"branch_filter":             flattenBuildDefinitionFilters(trigger.BranchFilters),
+	}
 }
 
-func resourceBuildDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(*config.AggregatedClient)
+func flattenGatedCheckInTrigger(trigger *gatedCheckInTrigger) interface{} {
+	useWorkspaceMappings := converter.ToBool(trigger.UseWorkspaceMappings, true)
+	pathFilters := []interface{}{}
+	if !useWorkspaceMappings {
+		pathFilters = flattenBuildDefinitionFilters(trigger.PathFilters)
+	}
+
+	return map[string]interface{}{
+		"run_continuous_integration": converter.ToBool(trigger.RunContinuousIntegration, false),
+		"use_workspace_mappings":     useWorkspaceMappings,
+		"path_filter":                pathFilters,
+	}
+}
+
+// the days of a schedule are a flags enum that AzDO serializes as a comma separated list,
+// e.g. `monday, friday`, or as `all`
+func flattenScheduleDays(days *build.ScheduleDays) []interface{} {
+	results := []interface{}{}
+	if days == nil {
+		return results
+	}
+
+	all := strings.EqualFold(string(*days), string(build.ScheduleDaysValues.All))
+	for _, rawDay := range strings.Split(string(*days), ",") {
+		for _, day := range scheduleDays {
+			if all || strings.EqualFold(strings.TrimSpace(rawDay), string(day)) {
+				results = append(results, strings.Title(string(day)))
+			}
+		}
+	}
+	return results
+}
+
+func flattenBuildDefinitionFilters(filters *[]string) []interface{} {
+	if filters == nil || len(*filters) == 0 {
+		return []interface{}{}
+	}
+
+	include := []interface{}{}
+	exclude := []interface{}{}
+	for _, filter := range *filters {
+		if strings.HasPrefix(filter, "-") {
+			exclude = append(exclude, strings.TrimPrefix(filter, "-"))
+		} else {
+			include = append(include, strings.TrimPrefix(filter, "+"))
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"include": include,
+		"exclude": exclude,
+	}}
+}
+
+func flattenVariableGroups(buildDefinition *build.BuildDefinition) []int {
+	if buildDefinition.VariableGroups == nil {
+		return nil
+	}
+
+	variableGroups := make([]int, len(*buildDefinition.VariableGroups))
+
+	for i, variableGroup := range *buildDefinition.VariableGroups {
+		variableGroups[i] = *variableGroup.Id
+	}
+
+	return variableGroups
+}
+
+func flattenBuildDefinitionVariables(d *schema.ResourceData, buildDefinition *build.BuildDefinition) []interface{} {
+	if buildDefinition.Variables == nil {
+		return []interface{}{}
+	}
+
+	// the configured secret values and the hashes of the previously applied values are needed
+	// to compute the hashes of the secrets, as AzDO does not return them
+	oldVariables, newVariables := d.GetChange("variable")
+	oldHashes := map[string]string{}
+	for _, raw := range oldVariables.(*schema.Set).List() {
+		variable := raw.(map[string]interface{})
+		oldHashes[variable["name"].(string)] = variable["value_hash"].(string)
+	}
+	secrets := map[string]string{}
+	for _, raw := range newVariables.(*schema.Set).List() {
+		variable := raw.(map[string]interface{})
+		secrets[variable["name"].(string)] = variable["value"].(string)
+	}
+
+	results := make([]interface{}, 0, len(*buildDefinition.Variables))
+	for name, variable := range *buildDefinition.Variables {
+		result := map[string]interface{}{
+			"name":           name,
+			"value":          converter.ToString(variable.Value, ""),
+			"value_hash":     "",
+			"is_secret":      converter.ToBool(variable.IsSecret, false),
+			"allow_override": converter.ToBool(variable.AllowOverride, false),
+		}
+
+		if converter.ToBool(variable.IsSecret, false) {
+			_, hash, err := secretmemo.IsUpdating(secrets[name], oldHashes[name])
+			if err != nil {
+				log.Printf("Swallowing err while using secret hashing: %s", err)
+			}
+			result["value_hash"] = hash
+		}
+
+		results = append(results, result)
+	}
+	return results
+}
+
+func createBuildDefinition(clients *config.AggregatedClient, buildDefinition *build.BuildDefinition, project string) (*build.BuildDefinition, error) {
+	createdBuild, err := clients.BuildClient.CreateDefinition(clients.Ctx, build.CreateDefinitionArgs{
+		Definition: buildDefinition,
+		Project:    &project,
+	})
+
+	return createdBuild, err
+}
+
+func resourceBuildDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID, buildDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+
+	if err != nil {
+		return err
+	}
+
+	buildDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      &projectID,
+		DefinitionId: &buildDefinitionID,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return flattenBuildDefinition(d, buildDefinition, projectID)
+}
+
+func resourceBuildDefinitionDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	clients := m.(*config.AggregatedClient)
+	projectID, buildDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	err = clients.BuildClient.DeleteDefinition(m.(*config.AggregatedClient).Ctx, build.DeleteDefinitionArgs{
+		Project:      &projectID,
+		DefinitionId: &buildDefinitionID,
+	})
+
+	return err
+}
+
+// build definitions are imported using the project and either the ID, the name or the full path of the definition:
+// project/definitionId, project/definitionName or project/folder\path\definitionName
+func importBuildDefinition(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*config.AggregatedClient)
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project/definitionId, project/definitionName or project/folder\\definitionName", d.Id())
+	}
+
+	project, err := projectRead(clients, parts[0], parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up project %s: %+v", parts[0], err)
+	}
+	projectID := project.Id.String()
+
+	buildDefinitionID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		buildDefinitionID, err = getBuildDefinitionIDByPath(clients, projectID, parts[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.Set("project_id", projectID)
+	d.SetId(strconv.Itoa(buildDefinitionID))
+	return []*schema.ResourceData{d}, nil
+}
+
+// resolves the ID of a build definition given its name, optionally prefixed with the folder it is stored in
+func getBuildDefinitionIDByPath(clients *config.AggregatedClient, projectID string, namePath string) (int, error) {
+	args := build.GetDefinitionsArgs{
+		Project: converter.String(projectID),
+		Name:    converter.String(namePath),
+	}
+	if i := strings.LastIndex(namePath, "\\"); i >= 0 {
+		args.Name = converter.String(namePath[i+1:])
+		args.Path = converter.String("\\" + strings.Trim(namePath[:i], "\\"))
+	}
+
+	definitions, err := clients.BuildClient.GetDefinitions(clients.Ctx, args)
+	if err != nil {
+		return 0, fmt.Errorf("Error looking up build definition %s in project %s: %+v", namePath, projectID, err)
+	}
+
+	switch len(definitions.Value) {
+	case 0:
+		return 0, fmt.Errorf("Build definition %s not found in project %s", namePath, projectID)
+	case 1:
+		return *definitions.Value[0].Id, nil
+	default:
+		return 0, fmt.Errorf("Found %d build definitions named %s in project %s. Import the build definition by its ID or full path instead", len(definitions.Value), namePath, projectID)
+	}
+}
+
+func resourceBuildDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
 	buildDefinition, projectID, err := expandBuildDefinition(d)
 	if err != nil {
 		return err
 	}
 
+	if err := resolveBuildDefinitionAgentQueue(clients, buildDefinition, projectID); err != nil {
+		return err
+	}
+
+	if err := keepBuildDefinitionUnmanagedSettings(clients, d, buildDefinition, projectID); err != nil {
+		return err
+	}
+
 	updatedBuildDefinition, err := clients.BuildClient.UpdateDefinition(m.(*config.AggregatedClient).Ctx, build.UpdateDefinitionArgs{
 		Definition:   buildDefinition,
 		Project:      &projectID,
@@ -208,10 +1139,133 @@ func resourceBuildDefinitionUpdate(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
-	flattenBuildDefinition(d, updatedBuildDefinition, projectID)
+	return flattenBuildDefinition(d, updatedBuildDefinition, projectID)
+}
+
+// copies the options and all settings which are not configured from the source definition of a new build definition
+func cloneBuildDefinitionSettings(clients *config.AggregatedClient, d *schema.ResourceData, buildDefinition *build.BuildDefinition, projectID string, sourceDefinitionID int) error {
+	sourceDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: converter.Int(sourceDefinitionID),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return fmt.Errorf("Build definition %d to copy the settings from does not exist in project %s", sourceDefinitionID, projectID)
+		}
+		return fmt.Errorf("Error looking up build definition %d in project %s: %+v", sourceDefinitionID, projectID, err)
+	}
+
+	// the options are not managed by Terraform, so they are always taken from the source definition
+	buildDefinition.Options = sourceDefinition.Options
+	if !isBuildDefinitionSettingConfigured(d, "variable") {
+		buildDefinition.Variables = sourceDefinition.Variables
+	}
+	if !isBuildDefinitionSettingConfigured(d, "retention_rule") {
+		buildDefinition.RetentionRules = sourceDefinition.RetentionRules
+	}
+
+	if sourceDefinition.Triggers == nil {
+		return nil
+	}
+	triggers := *buildDefinition.Triggers
+	for _, rawTrigger := range *sourceDefinition.Triggers {
+		var trigger buildDefinitionTrigger
+		if err := decodeBuildDefinitionTrigger(rawTrigger, &trigger); err != nil {
+			return err
+		}
+		if trigger.TriggerType == nil || !isBuildDefinitionTriggerTypeConfigured(d, *trigger.TriggerType) {
+			triggers = append(triggers, rawTrigger)
+		}
+	}
+	buildDefinition.Triggers = &triggers
+	return nil
+}
+
+func isBuildDefinitionSettingConfigured(d *schema.ResourceData, key string) bool {
+	switch setting := d.Get(key).(type) {
+	case *schema.Set:
+		return setting.Len() > 0
+	case []interface{}:
+		return len(setting) > 0
+	}
+	return false
+}
+
+func isBuildDefinitionTriggerTypeConfigured(d *schema.ResourceData, triggerType build.DefinitionTriggerType) bool {
+	for key, settingTriggerType := range clonedBuildDefinitionSettings {
+		if settingTriggerType != nil && *settingTriggerType == triggerType {
+			return isBuildDefinitionSettingConfigured(d, key)
+		}
+	}
+	// triggers which cannot be configured are always copied
+	return false
+}
+
+// the update replaces the whole build definition, so the settings which are managed in AzDO are taken from the
+// current build definition. These are the options and the steps of a designer process as well as the variables,
+// the demands, the retention rules and the triggers copied from a source definition if they are not configured.
+func keepBuildDefinitionUnmanagedSettings(clients *config.AggregatedClient, d *schema.ResourceData, buildDefinition *build.BuildDefinition, projectID string) error {
+	currentDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      converter.String(projectID),
+		DefinitionId: buildDefinition.Id,
+	})
+	if err != nil {
+		return fmt.Errorf("Error looking up the current settings of build definition %d in project %s: %+v", *buildDefinition.Id, projectID, err)
+	}
+
+	// the process is decoded from JSON into a generic map, in which numbers are floats
+	if *buildDefinition.Repository.Type == repoTypeTfsVersionControl {
+		if process, ok := currentDefinition.Process.(map[string]interface{}); ok && process["type"] == float64(processTypeDesigner) {
+			buildDefinition.Process = process
+		}
+	}
+	if buildDefinition.Variables == nil {
+		buildDefinition.Variables = currentDefinition.Variables
+	}
+	if buildDefinition.Demands == nil {
+		buildDefinition.Demands = currentDefinition.Demands
+	}
+	if buildDefinition.RetentionRules == nil {
+		buildDefinition.RetentionRules = currentDefinition.RetentionRules
+	}
+	buildDefinition.Options = currentDefinition.Options
+
+	if currentDefinition.Triggers == nil {
+		return nil
+	}
+	triggers := *buildDefinition.Triggers
+	for _, rawTrigger := range *currentDefinition.Triggers {
+		var trigger buildDefinitionTrigger
+		if err := decodeBuildDefinitionTrigger(rawTrigger, &trigger); err != nil {
+			return err
+		}
+		if trigger.TriggerType != nil && !isBuildDefinitionTriggerTypeManaged(d, *trigger.TriggerType) {
+			triggers = append(triggers, rawTrigger)
+		}
+	}
+	buildDefinition.Triggers = &triggers
 	return nil
 }
 
+// the triggers copied from the source definition of a cloned build definition are only managed by Terraform
+// once they are configured, like its variables. The triggers of other build definitions are always managed by Terraform.
+func isBuildDefinitionTriggerManaged(d *schema.ResourceData, key string) bool {
+	if _, ok := d.GetOk("source_definition_id"); !ok {
+		return true
+	}
+	return isBuildDefinitionSettingManaged(d, key)
+}
+
+func isBuildDefinitionTriggerTypeManaged(d *schema.ResourceData, triggerType build.DefinitionTriggerType) bool {
+	for key, settingTriggerType := range clonedBuildDefinitionSettings {
+		if settingTriggerType != nil && *settingTriggerType == triggerType {
+			return isBuildDefinitionTriggerManaged(d, key)
+		}
+	}
+	// triggers which cannot be configured are managed in AzDO
+	return false
+}
+
 func flattenRepository(buildDefiniton *build.BuildDefinition) interface{} {
 	yamlFilePath := ""
 
@@ -219,22 +1273,66 @@ func flattenRepository(buildDefiniton *build.BuildDefinition) interface{} {
 	// available from the compiler is `interface{}` so we can probe for known
 	// implementations
 	if processMap, ok := buildDefiniton.Process.(map[string]interface{}); ok {
-		yamlFilePath = processMap["yamlFilename"].(string)
+		// designer processes have no YAML file
+		yamlFilePath, _ = processMap["yamlFilename"].(string)
 	}
 
 	if yamlProcess, ok := buildDefiniton.Process.(*build.YamlProcess); ok {
 		yamlFilePath = *yamlProcess.YamlFilename
 	}
 
+	repository := buildDefiniton.Repository
+	repoType := converter.ToString(repository.Type, "")
+
+	// the URL is only part of the configuration for repositories on services other than the well-known ones
+	isAzureRepo := repoType == repoTypeTfsGit || repoType == repoTypeTfsVersionControl
+	repoURL := ""
+	if _, ok := buildRepositoryURLFormats[repoType]; !ok && !isAzureRepo {
+		repoURL = converter.ToString(repository.Url, "")
+	}
+
+	serviceConnectionID := ""
+	if !isAzureRepo && repository.Properties != nil {
+		serviceConnectionID = (*repository.Properties)["connectedServiceId"]
+	}
+
+	workspaceMappings := []interface{}{}
+	if repoType == repoTypeTfsVersionControl && repository.Properties != nil {
+		workspaceMappings = flattenTfvcWorkspaceMappings((*repository.Properties)["tfvcMapping"])
+	}
+
 	return []map[string]interface{}{{
 		"yml_path":              yamlFilePath,
-		"repo_name":             *buildDefiniton.Repository.Name,
-		"repo_type":             *buildDefiniton.Repository.Type,
-		"branch_name":           *buildDefiniton.Repository.DefaultBranch,
-		"service_connection_id": (*buildDefiniton.Repository.Properties)["connectedServiceId"],
+		"repo_name":             converter.ToString(repository.Name, ""),
+		"repo_type":             repoType,
+		"url":                   repoURL,
+		"branch_name":           converter.ToString(repository.DefaultBranch, ""),
+		"service_connection_id": serviceConnectionID,
+		"workspace_mapping":     workspaceMappings,
 	}}
 }
 
+func flattenTfvcWorkspaceMappings(rawMappings string) []interface{} {
+	results := []interface{}{}
+	var mappings tfvcWorkspaceMappings
+	if err := json.Unmarshal([]byte(rawMappings), &mappings); err != nil {
+		return results
+	}
+
+	for _, mapping := range mappings.Mappings {
+		localPath := ""
+		if mapping.MappingType == workspaceMappingTypeMap {
+			localPath = strings.TrimLeft(mapping.LocalPath, "\\")
+		}
+		results = append(results, map[string]interface{}{
+			"server_path":  mapping.ServerPath,
+			"mapping_type": mapping.MappingType,
+			"local_path":   localPath,
+		})
+	}
+	return results
+}
+
 func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, string, error) {
 	projectID := d.Get("project_id").(string)
 	repositories := d.Get("repository").(*schema.Set).List()
@@ -255,9 +1353,9 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 
 	repoName := repository["repo_name"].(string)
 	repoType := repository["repo_type"].(string)
-	repoURL := ""
-	if strings.EqualFold(repoType, "github") {
-		repoURL = fmt.Sprintf("https://github.com/%s.git", repoName)
+	repoID, repoURL, err := expandBuildRepositoryLocation(repository)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Look for the ID. This may not exist if we are within the context of a "create" operation,
@@ -270,38 +1368,707 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 		buildDefinitionReference = nil
 	}
 
-	agentPoolName := d.Get("agent_pool_name").(string)
+	variables := expandBuildDefinitionVariables(d)
+	demands := expandBuildDefinitionDemands(d)
+	retentionRules := expandBuildDefinitionRetentionRules(d)
+	queueStatus := build.DefinitionQueueStatus(d.Get("queue_status").(string))
+	jobAuthorizationScope := build.BuildAuthorizationScope(d.Get("job_authorization_scope").(string))
+
+	buildRepository := &build.BuildRepository{
+		Url:           &repoURL,
+		Id:            &repoID,
+		Name:          &repoName,
+		DefaultBranch: converter.String(repository["branch_name"].(string)),
+		Type:          &repoType,
+		Properties: &map[string]string{
+			"connectedServiceId": repository["service_connection_id"].(string),
+		},
+	}
+	var process interface{} = &build.YamlProcess{
+		YamlFilename: converter.String(repository["yml_path"].(string)),
+	}
+
+	if repoType == repoTypeTfsVersionControl {
+		if err := expandTfvcRepository(repository, buildRepository); err != nil {
+			return nil, "", err
+		}
+		process = newBuildDefinitionDesignerProcess()
+	} else if len(repository["workspace_mapping"].([]interface{})) > 0 {
+		return nil, "", fmt.Errorf("A workspace_mapping is only supported for repositories of type %s", repoTypeTfsVersionControl)
+	}
+
+	triggers, err := expandBuildDefinitionTriggers(d, repoType)
+	if err != nil {
+		return nil, "", err
+	}
+
 	buildDefinition := build.BuildDefinition{
-		Id:       buildDefinitionReference,
-		Name:     converter.String(d.Get("name").(string)),
-		Path:     converter.String(d.Get("path").(string)),
-		Revision: converter.Int(d.Get("revision").(int)),
-		Repository: &build.BuildRepository{
-			Url:           &repoURL,
-			Id:            &repoName,
-			Name:          &repoName,
-			DefaultBranch: converter.String(repository["branch_name"].(string)),
-			Type:          &repoType,
-			Properties: &map[string]string{
-				"connectedServiceId": repository["service_connection_id"].(string),
-			},
+		Id:                        buildDefinitionReference,
+		Name:                      converter.String(d.Get("name").(string)),
+		Path:                      converter.String(expandBuildDefinitionPath(d.Get("path").(string))),
+		Revision:                  converter.Int(d.Get("revision").(int)),
+		Repository:                buildRepository,
+		Process:                   process,
+		Queue:                     expandBuildDefinitionAgentQueue(d),
+		QueueStatus:               &queueStatus,
+		BuildNumberFormat:         converter.String(d.Get("build_number_format").(string)),
+		JobTimeoutInMinutes:       converter.Int(d.Get("job_timeout_in_minutes").(int)),
+		JobCancelTimeoutInMinutes: converter.Int(d.Get("job_cancel_timeout_in_minutes").(int)),
+		JobAuthorizationScope:     &jobAuthorizationScope,
+		BadgeEnabled:              converter.Bool(d.Get("badge_enabled").(bool)),
+		Demands:                   demands,
+		RetentionRules:            retentionRules,
+		Type:                      &build.DefinitionTypeValues.Build,
+		Quality:                   &build.DefinitionQualityValues.Definition,
+		VariableGroups:            &variableGroups,
+		Variables:                 variables,
+		Triggers:                  &triggers,
+	}
+
+	return &buildDefinition, projectID, nil
+}
+
+// agent demands are sent as strings, e.g. `java` or `Agent.OS -equals Linux`
+func expandBuildDefinitionDemands(d *schema.ResourceData) *[]interface{} {
+	if !isBuildDefinitionSettingManaged(d, "demands") {
+		return nil
+	}
+
+	demands := []interface{}{}
+	for _, demand := range d.Get("demands").(*schema.Set).List() {
+		demands = append(demands, demand.(string))
+	}
+	return &demands
+}
+
+func flattenBuildDefinitionDemands(buildDefinition *build.BuildDefinition) []interface{} {
+	demands := []interface{}{}
+	if buildDefinition.Demands == nil {
+		return demands
+	}
+
+	for _, demand := range *buildDefinition.Demands {
+		if v, ok := demand.(string); ok {
+			demands = append(demands, v)
+		}
+	}
+	return demands
+}
+
+// the variables, the demands and the retention rules of a build definition are only managed by Terraform if they are
+// configured, otherwise they are neither read nor expanded and an update sends back the settings made in AzDO. They
+// are still managed in the apply that removes them from the configuration, so that they are removed in AzDO as well.
+func isBuildDefinitionSettingManaged(d *schema.ResourceData, key string) bool {
+	oldValue, _ := d.GetChange(key)
+	for _, value := range []interface{}{oldValue, d.Get(key)} {
+		switch setting := value.(type) {
+		case *schema.Set:
+			if setting.Len() > 0 {
+				return true
+			}
+		case []interface{}:
+			if len(setting) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rules are evaluated in order by AzDO, builds not matched by any rule are retained according to the
+// retention settings of the project
+func expandBuildDefinitionRetentionRules(d *schema.ResourceData) *[]build.RetentionPolicy {
+	if !isBuildDefinitionSettingManaged(d, "retention_rule") {
+		return nil
+	}
+
+	rules := []build.RetentionPolicy{}
+	for _, raw := range d.Get("retention_rule").([]interface{}) {
+		rule := raw.(map[string]interface{})
+
+		artifactTypes := []string{}
+		for _, artifactType := range rule["artifact_types_to_delete"].(*schema.Set).List() {
+			artifactTypes = append(artifactTypes, artifactType.(string))
+		}
+		sort.Strings(artifactTypes)
+
+		rules = append(rules, build.RetentionPolicy{
+			Branches:              expandBuildDefinitionFilters(rule["branch_filter"].([]interface{})),
+			DaysToKeep:            converter.Int(rule["days_to_keep"].(int)),
+			MinimumToKeep:         converter.Int(rule["minimum_to_keep"].(int)),
+			DeleteBuildRecord:     converter.Bool(rule["delete_build_record"].(bool)),
+			DeleteTestResults:     converter.Bool(rule["delete_test_results"].(bool)),
+			ArtifactTypesToDelete: &artifactTypes,
+		})
+	}
+	return &rules
+}
+
+func flattenBuildDefinitionRetentionRules(buildDefinition *build.BuildDefinition) []interface{} {
+	rules := []interface{}{}
+	if buildDefinition.RetentionRules == nil {
+		return rules
+	}
+
+	for _, rule := range *buildDefinition.RetentionRules {
+		artifactTypes := []interface{}{}
+		if rule.ArtifactTypesToDelete != nil {
+			for _, artifactType := range *rule.ArtifactTypesToDelete {
+				artifactTypes = append(artifactTypes, artifactType)
+			}
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"branch_filter":            flattenBuildDefinitionFilters(rule.Branches),
+			"days_to_keep":             converter.ToInt(rule.DaysToKeep, 0),
+			"minimum_to_keep":          converter.ToInt(rule.MinimumToKeep, 0),
+			"delete_build_record":      converter.ToBool(rule.DeleteBuildRecord, false),
+			"delete_test_results":      converter.ToBool(rule.DeleteTestResults, false),
+			"artifact_types_to_delete": artifactTypes,
+		})
+	}
+	return rules
+}
+
+// the queue is referenced either by its ID or by its name. References by name are resolved
+// to the ID of the queue before the build definition is sent to AzDO.
+func expandBuildDefinitionAgentQueue(d *schema.ResourceData) *build.AgentPoolQueue {
+	queue := &build.AgentPoolQueue{}
+	if queueID := d.Get("agent_queue_id").(int); queueID > 0 {
+		queue.Id = converter.Int(queueID)
+	}
+	if queueName := d.Get("agent_pool_name").(string); queueName != "" {
+		queue.Name = converter.String(queueName)
+	}
+	if queue.Id == nil && queue.Name == nil {
+		queue.Name = converter.String(defaultBuildDefinitionAgentQueueName)
+	}
+	return queue
+}
+
+// looks up the agent queue referenced by the build definition in the project, as the names of a queue
+// and of the pool it belongs to can differ
+func resolveBuildDefinitionAgentQueue(clients *config.AggregatedClient, buildDefinition *build.BuildDefinition, projectID string) error {
+	queue, err := getAgentQueue(clients, projectID, converter.ToInt(buildDefinition.Queue.Id, 0), converter.ToString(buildDefinition.Queue.Name, ""))
+	if err != nil {
+		return err
+	}
+
+	buildDefinition.Queue = &build.AgentPoolQueue{
+		Id:   queue.Id,
+		Name: queue.Name,
+	}
+	return nil
+}
+
+func getAgentQueue(clients *config.AggregatedClient, projectID string, queueID int, queueName string) (*taskagent.TaskAgentQueue, error) {
+	if queueID > 0 {
+		queue, err := clients.TaskAgentClient.GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{
+			Project: converter.String(projectID),
+			QueueId: converter.Int(queueID),
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return nil, fmt.Errorf("Error looking up agent queue %d in project %s: %+v", queueID, projectID, err)
+		}
+		if queue == nil || queue.Id == nil {
+			return nil, fmt.Errorf("Agent queue %d does not exist in project %s", queueID, projectID)
+		}
+		return queue, nil
+	}
+
+	queues, err := clients.TaskAgentClient.GetAgentQueues(clients.Ctx, taskagent.GetAgentQueuesArgs{
+		Project:   converter.String(projectID),
+		QueueName: converter.String(queueName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up agent queue %s in project %s: %+v", queueName, projectID, err)
+	}
+	if queues != nil {
+		for _, queue := range *queues {
+			if strings.EqualFold(converter.ToString(queue.Name, ""), queueName) {
+				return &queue, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Agent queue %s does not exist in project %s", queueName, projectID)
+}
+
+// verifies at plan time that the settings required by the type of the repository are configured and that the
+// resources referenced by the build definition exist in the project
+func customizeBuildDefinitionDiff(d *schema.ResourceDiff, m interface{}) error {
+	if err := customizeBuildDefinitionRepositoryDiff(d); err != nil {
+		return err
+	}
+	if err := customizeBuildDefinitionAgentQueueDiff(d, m); err != nil {
+		return err
+	}
+	return customizeBuildDefinitionCompletionTriggerDiff(d, m)
+}
+
+// YAML pipelines and triggers on branches are required for all repositories except TFVC repositories, whose
+// settings are validated when the build definition is expanded. The check is skipped if the repository is not known yet.
+func customizeBuildDefinitionRepositoryDiff(d *schema.ResourceDiff) error {
+	repositories := d.Get("repository").(*schema.Set).List()
+	if !d.NewValueKnown("repository") || len(repositories) != 1 {
+		return nil
+	}
+
+	repository := repositories[0].(map[string]interface{})
+	repoType := repository["repo_type"].(string)
+	if repoType == "" || repoType == repoTypeTfsVersionControl {
+		return nil
+	}
+
+	if repository["yml_path"].(string) == "" {
+		return fmt.Errorf("A yml_path is required for repositories of type %s", repoType)
+	}
+
+	for _, rawTrigger := range d.Get("ci_trigger").([]interface{}) {
+		ci, ok := rawTrigger.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, rawOverride := range ci["override"].([]interface{}) {
+			if override, ok := rawOverride.(map[string]interface{}); ok && len(override["branch_filter"].([]interface{})) == 0 {
+				return fmt.Errorf("The override of a ci_trigger requires a branch_filter for repositories of type %s", repoType)
+			}
+		}
+	}
+	return nil
+}
+
+// As both attributes referencing the agent queue are computed, switching from one kind of reference to the
+// other recomputes the attribute no longer configured. The check is skipped if the project or the queue
+// are not known yet, e.g. because they are created in the same apply.
+func customizeBuildDefinitionAgentQueueDiff(d *schema.ResourceDiff, m interface{}) error {
+	nameChanged := d.HasChange("agent_pool_name")
+	idChanged := d.HasChange("agent_queue_id")
+	if nameChanged && !idChanged {
+		if err := d.SetNewComputed("agent_queue_id"); err != nil {
+			return err
+		}
+	}
+	if idChanged && !nameChanged {
+		if err := d.SetNewComputed("agent_pool_name"); err != nil {
+			return err
+		}
+	}
+	if !d.NewValueKnown("project_id") || (!nameChanged && !idChanged && !d.HasChange("project_id")) {
+		return nil
+	}
+
+	queueID := 0
+	queueName := ""
+	if d.NewValueKnown("agent_pool_name") {
+		queueName = d.Get("agent_pool_name").(string)
+	}
+	if queueName == "" && d.NewValueKnown("agent_queue_id") {
+		queueID = d.Get("agent_queue_id").(int)
+	}
+	if queueID == 0 && queueName == "" {
+		return nil
+	}
+
+	clients := m.(*config.AggregatedClient)
+	_, err := getAgentQueue(clients, d.Get("project_id").(string), queueID, queueName)
+	return err
+}
+
+// upstream definitions have to be part of the same project. Definitions which are not known yet, e.g.
+// because they are created in the same apply, are skipped.
+func customizeBuildDefinitionCompletionTriggerDiff(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("project_id") || (!d.HasChange("build_completion_trigger") && !d.HasChange("project_id")) {
+		return nil
+	}
+
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	for i := range d.Get("build_completion_trigger").([]interface{}) {
+		key := fmt.Sprintf("build_completion_trigger.%d.definition_id", i)
+		if !d.NewValueKnown(key) {
+			continue
+		}
+
+		definitionID := d.Get(key).(int)
+		if strconv.Itoa(definitionID) == d.Id() {
+			return fmt.Errorf("A build definition cannot be triggered by the completion of its own builds")
+		}
+
+		_, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+			Project:      converter.String(projectID),
+			DefinitionId: converter.Int(definitionID),
+		})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				return fmt.Errorf("Build definition %d of the build_completion_trigger does not exist in project %s", definitionID, projectID)
+			}
+			return fmt.Errorf("Error looking up build definition %d in project %s: %+v", definitionID, projectID, err)
+		}
+	}
+	return nil
+}
+
+// TFVC repositories are built from the root server path configured as branch_name, the workspace
+// mappings are sent as a JSON document in the properties of the repository
+func expandTfvcRepository(repository map[string]interface{}, buildRepository *build.BuildRepository) error {
+	rootFolder := repository["branch_name"].(string)
+	if !strings.HasPrefix(rootFolder, "$/") {
+		return fmt.Errorf("The branch_name of a repository of type %s must be its root server path, e.g. $/project, got: %s", repoTypeTfsVersionControl, rootFolder)
+	}
+	if repository["yml_path"].(string) != "" {
+		return fmt.Errorf("A yml_path is not supported for repositories of type %s, YAML pipelines only build Git repositories", repoTypeTfsVersionControl)
+	}
+
+	mappings := tfvcWorkspaceMappings{Mappings: []tfvcWorkspaceMapping{}}
+	hasMap := false
+	for _, rawMapping := range repository["workspace_mapping"].([]interface{}) {
+		mapping := rawMapping.(map[string]interface{})
+		mappingType := mapping["mapping_type"].(string)
+		localPath := ""
+		if mappingType == workspaceMappingTypeMap {
+			hasMap = true
+			// the local path is relative to the sources directory of the agent, which is `\`
+			localPath = "\\" + strings.TrimLeft(mapping["local_path"].(string), "\\")
+		}
+		mappings.Mappings = append(mappings.Mappings, tfvcWorkspaceMapping{
+			ServerPath:  mapping["server_path"].(string),
+			MappingType: mappingType,
+			LocalPath:   localPath,
+		})
+	}
+	if !hasMap {
+		return fmt.Errorf("A workspace_mapping of type %s is required for repositories of type %s", workspaceMappingTypeMap, repoTypeTfsVersionControl)
+	}
+
+	rawMappings, err := json.Marshal(mappings)
+	if err != nil {
+		return fmt.Errorf("Error converting the workspace mappings of the repository: %+v", err)
+	}
+
+	buildRepository.RootFolder = converter.String(rootFolder)
+	buildRepository.Properties = &map[string]string{
+		"tfvcMapping": string(rawMappings),
+	}
+	return nil
+}
+
+// a new designer process consists of a single agent job without any steps, the steps are added in AzDO
+func newBuildDefinitionDesignerProcess() *designerProcess {
+	return &designerProcess{
+		Type: converter.Int(processTypeDesigner),
+		DesignerProcess: build.DesignerProcess{
+			Phases: &[]build.Phase{{
+				Name:      converter.String("Agent job 1"),
+				RefName:   converter.String("Job_1"),
+				Condition: converter.String("succeeded()"),
+				Target:    &build.PhaseTarget{Type: converter.Int(1)},
+				Steps:     &[]build.BuildDefinitionStep{},
+			}},
 		},
-		Process: &build.YamlProcess{
-			YamlFilename: converter.String(repository["yml_path"].(string)),
+	}
+}
+
+// returns the ID and the URL of the repository that is built
+func expandBuildRepositoryLocation(repository map[string]interface{}) (string, string, error) {
+	repoName := repository["repo_name"].(string)
+	repoType := repository["repo_type"].(string)
+	repoURL := repository["url"].(string)
+
+	// AzDO can only access repositories on these services through a service connection
+	if (repoType == repoTypeBitbucket || repoType == repoTypeGitHubEnterprise) && repository["service_connection_id"].(string) == "" {
+		return "", "", fmt.Errorf("A service_connection_id is required for repositories of type %s", repoType)
+	}
+
+	if format, ok := buildRepositoryURLFormats[repoType]; ok {
+		return repoName, fmt.Sprintf(format, repoName), nil
+	}
+
+	switch repoType {
+	case repoTypeTfsGit:
+		return repoName, "", nil
+	case repoTypeTfsVersionControl:
+		// a project has a single TFVC repository, which is identified by the root of its server paths
+		return "$/", "", nil
+	case repoTypeGit:
+		// external Git repositories are identified by their URL
+		if repoURL == "" {
+			return "", "", fmt.Errorf("A url is required for repositories of type %s", repoType)
+		}
+		return repoURL, repoURL, nil
+	default:
+		if repoURL == "" {
+			return "", "", fmt.Errorf("A url is required for repositories of type %s", repoType)
+		}
+		return repoName, repoURL, nil
+	}
+}
+
+func expandBuildDefinitionVariables(d *schema.ResourceData) *map[string]build.BuildDefinitionVariable {
+	if !isBuildDefinitionSettingManaged(d, "variable") {
+		return nil
+	}
+
+	variables := map[string]build.BuildDefinitionVariable{}
+	for _, raw := range d.Get("variable").(*schema.Set).List() {
+		variable := raw.(map[string]interface{})
+		isSecret := variable["is_secret"].(bool)
+
+		// AzDO keeps the current value of a secret variable if no value is sent
+		var value *string
+		if !isSecret || variable["value"].(string) != "" {
+			value = converter.String(variable["value"].(string))
+		}
+
+		variables[variable["name"].(string)] = build.BuildDefinitionVariable{
+			Value:         value,
+			IsSecret:      converter.Bool(isSecret),
+			AllowOverride: converter.Bool(variable["allow_override"].(bool)),
+		}
+	}
+	return &variables
+}
+
+func expandBuildDefinitionTriggers(d *schema.ResourceData, repoType string) ([]interface{}, error) {
+	triggers := []interface{}{}
+
+	ciTriggers := d.Get("ci_trigger").([]interface{})
+	if len(ciTriggers) == 1 {
+		trigger, err := expandCiTrigger(ciTriggers[0], repoType)
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	pullRequestTriggers := d.Get("pull_request_trigger").([]interface{})
+	if len(pullRequestTriggers) == 1 {
+		if repoType == repoTypeTfsVersionControl {
+			return nil, fmt.Errorf("A pull_request_trigger is not supported for repositories of type %s", repoType)
+		}
+		trigger, err := expandPullRequestTrigger(pullRequestTriggers[0])
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	schedules := d.Get("schedule").([]interface{})
+	if len(schedules) > 0 {
+		triggers = append(triggers, expandScheduleTrigger(schedules))
+	}
+
+	// every upstream definition is a trigger on its own
+	for _, rawTrigger := range d.Get("build_completion_trigger").([]interface{}) {
+		triggers = append(triggers, expandBuildCompletionTrigger(rawTrigger.(map[string]interface{})))
+	}
+
+	gatedCheckInTriggers := d.Get("gated_checkin_trigger").([]interface{})
+	if len(gatedCheckInTriggers) == 1 {
+		if repoType != repoTypeTfsVersionControl {
+			return nil, fmt.Errorf("A gated_checkin_trigger is only supported for repositories of type %s", repoTypeTfsVersionControl)
+		}
+		trigger, err := expandGatedCheckInTrigger(gatedCheckInTriggers[0])
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+func expandCiTrigger(rawTrigger interface{}, repoType string) (*ciTrigger, error) {
+	trigger := &ciTrigger{
+		TriggerType: &build.DefinitionTriggerTypeValues.ContinuousIntegration,
+	}
+
+	var ci map[string]interface{}
+	if rawTrigger != nil {
+		ci = rawTrigger.(map[string]interface{})
+	}
+	if ci != nil && ci["use_yaml"].(bool) {
+		if repoType == repoTypeTfsVersionControl {
+			return nil, fmt.Errorf("A ci_trigger of a repository of type %s requires an override block, YAML pipelines only build Git repositories", repoType)
+		}
+		trigger.SettingsSourceType = converter.Int(triggerSettingsSourceTypeYaml)
+		trigger.BranchFilters = &[]string{}
+		trigger.PathFilters = &[]string{}
+		trigger.BatchChanges = converter.Bool(false)
+		trigger.MaxConcurrentBuildsPerBranch = converter.Int(1)
+		return trigger, nil
+	}
+
+	if ci == nil || len(ci["override"].([]interface{})) != 1 || ci["override"].([]interface{})[0] == nil {
+		return nil, fmt.Errorf("A ci_trigger requires either use_yaml to be set or an override block")
+	}
+
+	override := ci["override"].([]interface{})[0].(map[string]interface{})
+	branchFilters := override["branch_filter"].([]interface{})
+	pathFilters := override["path_filter"].([]interface{})
+
+	// TFVC has no branches, its builds are triggered by changes to server paths instead
+	if repoType == repoTypeTfsVersionControl {
+		if len(branchFilters) > 0 {
+			return nil, fmt.Errorf("A branch_filter is not supported for repositories of type %s, use a path_filter with server paths instead", repoType)
+		}
+		if err := validateTfvcPathFilters(pathFilters); err != nil {
+			return nil, err
+		}
+	}
+
+	trigger.SettingsSourceType = converter.Int(triggerSettingsSourceTypeDefinition)
+	trigger.BatchChanges = converter.Bool(override["batch"].(bool))
+	trigger.MaxConcurrentBuildsPerBranch = converter.Int(override["max_concurrent_builds_per_branch"].(int))
+	trigger.BranchFilters = expandBuildDefinitionFilters(branchFilters)
+	trigger.PathFilters = expandBuildDefinitionFilters(pathFilters)
+	return trigger, nil
+}
+
+// a gated check-in either builds the shelvesets of the mapped server paths or of the paths of its own filter
+func expandGatedCheckInTrigger(rawTrigger interface{}) (*gatedCheckInTrigger, error) {
+	trigger := &gatedCheckInTrigger{
+		TriggerType: &build.DefinitionTriggerTypeValues.GatedCheckIn,
+	}
+
+	// an empty block is not part of the resource data, so the defaults of the schema apply
+	useWorkspaceMappings := true
+	runContinuousIntegration := false
+	pathFilters := []interface{}{}
+	if rawTrigger != nil {
+		gated := rawTrigger.(map[string]interface{})
+		useWorkspaceMappings = gated["use_workspace_mappings"].(bool)
+		runContinuousIntegration = gated["run_continuous_integration"].(bool)
+		pathFilters = gated["path_filter"].([]interface{})
+	}
+
+	if !useWorkspaceMappings {
+		if len(pathFilters) == 0 {
+			return nil, fmt.Errorf("A gated_checkin_trigger requires a path_filter if use_workspace_mappings is disabled")
+		}
+		if err := validateTfvcPathFilters(pathFilters); err != nil {
+			return nil, err
+		}
+	}
+
+	trigger.UseWorkspaceMappings = converter.Bool(useWorkspaceMappings)
+	trigger.RunContinuousIntegration = converter.Bool(runContinuousIntegration)
+	trigger.PathFilters = expandBuildDefinitionFilters(pathFilters)
+	return trigger, nil
+}
+
+func validateTfvcPathFilters(rawFilters []interface{}) error {
+	for _, filter := range *expandBuildDefinitionFilters(rawFilters) {
+		if _, errors := validateTfvcServerPath(filter[1:], "path_filter"); len(errors) > 0 {
+			return errors[0]
+		}
+	}
+	return nil
+}
+
+func expandPullRequestTrigger(rawTrigger interface{}) (*pullRequestTrigger, error) {
+	if rawTrigger == nil {
+		return nil, fmt.Errorf("A pull_request_trigger requires a forks block")
+	}
+	pr := rawTrigger.(map[string]interface{})
+
+	trigger := &pullRequestTrigger{
+		TriggerType: &build.DefinitionTriggerTypeValues.PullRequest,
+	}
+
+	commentRequired := pr["comment_required"].(string)
+	trigger.IsCommentRequiredForPullRequest = converter.Bool(commentRequired != "")
+	trigger.RequireCommentsForNonTeamMembersOnly = converter.Bool(commentRequired == pullRequestCommentRequiredNonTeamMembers)
+
+	forks := pr["forks"].([]interface{})
+	if len(forks) != 1 || forks[0] == nil {
+		return nil, fmt.Errorf("A pull_request_trigger requires a forks block")
+	}
+	trigger.Forks = &build.Forks{
+		Enabled:      converter.Bool(forks[0].(map[string]interface{})["enabled"].(bool)),
+		AllowSecrets: converter.Bool(forks[0].(map[string]interface{})["share_secrets"].(bool)),
+	}
+
+	if pr["use_yaml"].(bool) {
+		trigger.SettingsSourceType = converter.Int(triggerSettingsSourceTypeYaml)
+		trigger.BranchFilters = &[]string{}
+		trigger.PathFilters = &[]string{}
+		trigger.AutoCancel = converter.Bool(true)
+		return trigger, nil
+	}
+
+	if len(pr["override"].([]interface{})) != 1 || pr["override"].([]interface{})[0] == nil {
+		return nil, fmt.Errorf("A pull_request_trigger requires either use_yaml to be set or an override block")
+	}
+
+	override := pr["override"].([]interface{})[0].(map[string]interface{})
+	trigger.SettingsSourceType = converter.Int(triggerSettingsSourceTypeDefinition)
+	trigger.AutoCancel = converter.Bool(override["auto_cancel"].(bool))
+	trigger.BranchFilters = expandBuildDefinitionFilters(override["branch_filter"].([]interface{}))
+	trigger.PathFilters = expandBuildDefinitionFilters(override["path_filter"].([]interface{}))
+	return trigger, nil
+}
+
+func expandScheduleTrigger(rawSchedules []interface{}) *scheduleTrigger {
+	schedules := make([]build.Schedule, 0, len(rawSchedules))
+	for _, rawSchedule := range rawSchedules {
+		schedule := rawSchedule.(map[string]interface{})
+		schedules = append(schedules, build.Schedule{
+			DaysToBuild:             expandScheduleDays(schedule["days_to_build"].(*schema.Set)),
+			StartHours:              converter.Int(schedule["start_hours"].(int)),
+			StartMinutes:            converter.Int(schedule["start_minutes"].(int)),
+			TimeZoneId:              converter.String(schedule["time_zone"].(string)),
+			ScheduleOnlyWithChanges: converter.Bool(schedule["schedule_only_with_changes"].(bool)),
+			BranchFilters:           expandBuildDefinitionFilters(schedule["branch_filter"].([]interface{})),
+		})
+	}
+
+	return &scheduleTrigger{
+		ScheduleTrigger: build.ScheduleTrigger{
+			Schedules: &schedules,
 		},
-		Queue: &build.AgentPoolQueue{
-			Name: &agentPoolName,
-			Pool: &build.TaskAgentPoolReference{
-				Name: &agentPoolName,
+		TriggerType: &build.DefinitionTriggerTypeValues.Schedule,
+	}
+}
+
+func expandBuildCompletionTrigger(completion map[string]interface{}) *buildCompletionTrigger {
+	return &buildCompletionTrigger{
+		BuildCompletionTrigger: build.BuildCompletionTrigger{
+			Definition: &build.DefinitionReference{
+				Id: converter.Int(completion["definition_id"].(int)),
 			},
+			RequiresSuccessfulBuild: converter.Bool(completion["requires_successful_build"].(bool)),
+			BranchFilters:           expandBuildDefinitionFilters(completion["branch_filter"].([]interface{})),
 		},
-		QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
-		Type:           &build.DefinitionTypeValues.Build,
-		Quality:        &build.DefinitionQualityValues.Definition,
-		VariableGroups: &variableGroups,
+		TriggerType: &build.DefinitionTriggerTypeValues.BuildCompletion,
 	}
+}
 
-	return &buildDefinition, projectID, nil
+func expandScheduleDays(rawDays *schema.Set) *build.ScheduleDays {
+	days := []string{}
+	for _, day := range scheduleDays {
+		if rawDays.Contains(strings.Title(string(day))) {
+			days = append(days, string(day))
+		}
+	}
+
+	result := build.ScheduleDays(strings.Join(days, ", "))
+	return &result
+}
+
+func expandBuildDefinitionFilters(rawFilters []interface{}) *[]string {
+	filters := []string{}
+	if len(rawFilters) != 1 || rawFilters[0] == nil {
+		return &filters
+	}
+
+	filter := rawFilters[0].(map[string]interface{})
+	for _, include := range filter["include"].([]interface{}) {
+		filters = append(filters, "+"+include.(string))
+	}
+	for _, exclude := range filter["exclude"].([]interface{}) {
+		filters = append(filters, "-"+exclude.(string))
+	}
+	return &filters
 }
 
 func buildVariableGroup(id int) *build.VariableGroup {
@@ -6,8 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/secretmemo"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
 	"strconv"
 	"testing"
@@ -18,13 +20,21 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
 	"github.com/stretchr/testify/require"
 )
 
 var testProjectID = uuid.New().String()
 
+var testAgentQueue = taskagent.TaskAgentQueue{
+	Id:   converter.Int(5),
+	Name: converter.String("BuildPoolName"),
+}
+
 // This definition matches the overall structure of what a configured git repository would
 // look like. Note that the ID and Name attributes match -- this is the service-side behavior
 // when configuring a GitHub repo.
@@ -47,15 +57,104 @@ var testBuildDefinition = build.BuildDefinition{
 		YamlFilename: converter.String("YamlFilename"),
 	},
 	Queue: &build.AgentPoolQueue{
+		Id:   converter.Int(5),
 		Name: converter.String("BuildPoolName"),
-		Pool: &build.TaskAgentPoolReference{
-			Name: converter.String("BuildPoolName"),
+	},
+	QueueStatus:               &build.DefinitionQueueStatusValues.Enabled,
+	BuildNumberFormat:         converter.String("$(date:yyyyMMdd)$(rev:.r)"),
+	JobTimeoutInMinutes:       converter.Int(60),
+	JobCancelTimeoutInMinutes: converter.Int(5),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.Project,
+	BadgeEnabled:              converter.Bool(true),
+	Demands:                   &[]interface{}{"java"},
+	RetentionRules: &[]build.RetentionPolicy{
+		{
+			Branches:              &[]string{"+refs/heads/master", "-refs/heads/releases/*"},
+			DaysToKeep:            converter.Int(30),
+			MinimumToKeep:         converter.Int(5),
+			DeleteBuildRecord:     converter.Bool(true),
+			DeleteTestResults:     converter.Bool(false),
+			ArtifactTypesToDelete: &[]string{"FilePath", "SymbolStore"},
 		},
 	},
-	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
 	Type:           &build.DefinitionTypeValues.Build,
 	Quality:        &build.DefinitionQualityValues.Definition,
 	VariableGroups: &[]build.VariableGroup{},
+	Variables: &map[string]build.BuildDefinitionVariable{
+		"plain": {
+			Value:         converter.String("value"),
+			IsSecret:      converter.Bool(false),
+			AllowOverride: converter.Bool(true),
+		},
+		"secret": {
+			IsSecret:      converter.Bool(true),
+			AllowOverride: converter.Bool(false),
+		},
+	},
+	Triggers: &[]interface{}{
+		&ciTrigger{
+			ContinuousIntegrationTrigger: build.ContinuousIntegrationTrigger{
+				BatchChanges:                 converter.Bool(true),
+				MaxConcurrentBuildsPerBranch: converter.Int(2),
+				BranchFilters:                &[]string{"+master", "+releases/*", "-releases/old*"},
+				PathFilters:                  &[]string{"-docs/*"},
+				SettingsSourceType:           converter.Int(triggerSettingsSourceTypeDefinition),
+			},
+			TriggerType: &build.DefinitionTriggerTypeValues.ContinuousIntegration,
+		},
+		&pullRequestTrigger{
+			PullRequestTrigger: build.PullRequestTrigger{
+				AutoCancel:                           converter.Bool(false),
+				BranchFilters:                        &[]string{"+master"},
+				PathFilters:                          &[]string{},
+				IsCommentRequiredForPullRequest:      converter.Bool(true),
+				RequireCommentsForNonTeamMembersOnly: converter.Bool(true),
+				Forks: &build.Forks{
+					Enabled:      converter.Bool(true),
+					AllowSecrets: converter.Bool(false),
+				},
+				SettingsSourceType: converter.Int(triggerSettingsSourceTypeDefinition),
+			},
+			TriggerType: &build.DefinitionTriggerTypeValues.PullRequest,
+		},
+		&scheduleTrigger{
+			ScheduleTrigger: build.ScheduleTrigger{
+				Schedules: &[]build.Schedule{
+					{
+						BranchFilters:           &[]string{"+master"},
+						DaysToBuild:             scheduleDaysPointer("monday, wednesday, friday"),
+						ScheduleOnlyWithChanges: converter.Bool(true),
+						StartHours:              converter.Int(3),
+						StartMinutes:            converter.Int(30),
+						TimeZoneId:              converter.String("W. Europe Standard Time"),
+					},
+				},
+			},
+			TriggerType: &build.DefinitionTriggerTypeValues.Schedule,
+		},
+		&buildCompletionTrigger{
+			BuildCompletionTrigger: build.BuildCompletionTrigger{
+				Definition:              &build.DefinitionReference{Id: converter.Int(42)},
+				RequiresSuccessfulBuild: converter.Bool(true),
+				BranchFilters:           &[]string{"+master"},
+			},
+			TriggerType: &build.DefinitionTriggerTypeValues.BuildCompletion,
+		},
+	},
+}
+
+// flattens the build definition into resource data that manages its variables, demands and retention rules,
+// as these settings are only read once they are configured
+func flattenManagedBuildDefinition(resourceData *schema.ResourceData, buildDefinition *build.BuildDefinition) error {
+	resourceData.Set("demands", []interface{}{"managed"})
+	resourceData.Set("retention_rule", []interface{}{map[string]interface{}{"days_to_keep": 1}})
+	resourceData.Set("variable", []interface{}{map[string]interface{}{"name": "managed"}})
+	return flattenBuildDefinition(resourceData, buildDefinition, testProjectID)
+}
+
+func scheduleDaysPointer(days string) *build.ScheduleDays {
+	result := build.ScheduleDays(days)
+	return &result
 }
 
 /**
@@ -64,7 +163,7 @@ var testBuildDefinition = build.BuildDefinition{
 
 // validates that all supported repo types are allowed by the schema
 func TestAzureDevOpsBuildDefinition_RepoTypeListIsCorrect(t *testing.T) {
-	expectedRepoTypes := []string{"GitHub", "TfsGit"}
+	expectedRepoTypes := []string{"GitHub", "TfsGit", "GitHubEnterprise", "Bitbucket", "Git", "TfsVersionControl"}
 	repoSchema := resourceBuildDefinition().Schema["repository"]
 	repoTypeSchema := repoSchema.Elem.(*schema.Resource).Schema["repo_type"]
 
@@ -74,6 +173,239 @@ func TestAzureDevOpsBuildDefinition_RepoTypeListIsCorrect(t *testing.T) {
 	}
 }
 
+// verifies that the ID and URL of the repository are derived correctly for all supported repo types
+func TestAzureDevOpsBuildDefinition_Expand_RepositoryLocation(t *testing.T) {
+	cases := []struct {
+		RepoType            string
+		URL                 string
+		ServiceConnectionID string
+		ExpectedID          string
+		ExpectedURL         string
+		ExpectedError       string
+	}{
+		{"GitHub", "", "", "org/repo", "https://github.com/org/repo.git", ""},
+		{"Bitbucket", "", "ServiceConnectionID", "org/repo", "https://bitbucket.org/org/repo.git", ""},
+		{"Bitbucket", "", "", "", "", "service_connection_id"},
+		{"GitHubEnterprise", "https://github.contoso.com/org/repo.git", "ServiceConnectionID", "org/repo", "https://github.contoso.com/org/repo.git", ""},
+		{"GitHubEnterprise", "", "ServiceConnectionID", "", "", "url"},
+		{"Git", "https://git.contoso.com/repo.git", "", "https://git.contoso.com/repo.git", "https://git.contoso.com/repo.git", ""},
+		{"Git", "", "ServiceConnectionID", "", "", "url"},
+		{"TfsGit", "", "", "org/repo", "", ""},
+		{"TfsVersionControl", "", "", "$/", "", ""},
+	}
+
+	for _, tc := range cases {
+		id, url, err := expandBuildRepositoryLocation(map[string]interface{}{
+			"repo_name":             "org/repo",
+			"repo_type":             tc.RepoType,
+			"url":                   tc.URL,
+			"service_connection_id": tc.ServiceConnectionID,
+		})
+
+		if tc.ExpectedError != "" {
+			require.NotNil(t, err, tc.RepoType)
+			require.Contains(t, err.Error(), tc.ExpectedError)
+			continue
+		}
+		require.Nil(t, err, tc.RepoType)
+		require.Equal(t, tc.ExpectedID, id, tc.RepoType)
+		require.Equal(t, tc.ExpectedURL, url, tc.RepoType)
+	}
+}
+
+// verifies that the URL is only flattened for repo types that are configured with a URL
+func TestAzureDevOpsBuildDefinition_Flatten_RepositoryURL(t *testing.T) {
+	buildDefinition := testBuildDefinition
+	buildDefinition.Repository = &build.BuildRepository{
+		Url:           converter.String("https://github.contoso.com/org/repo.git"),
+		Id:            converter.String("org/repo"),
+		Name:          converter.String("org/repo"),
+		DefaultBranch: converter.String("master"),
+		Type:          converter.String("GitHubEnterprise"),
+		Properties: &map[string]string{
+			"connectedServiceId": "ServiceConnectionID",
+		},
+	}
+	repository := flattenRepository(&buildDefinition).([]map[string]interface{})[0]
+	require.Equal(t, "https://github.contoso.com/org/repo.git", repository["url"])
+	require.Equal(t, "ServiceConnectionID", repository["service_connection_id"])
+
+	buildDefinition.Repository = &build.BuildRepository{
+		Url:           converter.String("https://dev.azure.com/org/project/_git/repo"),
+		Id:            converter.String("repo"),
+		Name:          converter.String("repo"),
+		DefaultBranch: converter.String("master"),
+		Type:          converter.String("TfsGit"),
+	}
+	repository = flattenRepository(&buildDefinition).([]map[string]interface{})[0]
+	require.Equal(t, "", repository["url"])
+	require.Equal(t, "", repository["service_connection_id"])
+}
+
+func testTfvcBuildDefinitionResourceData(t *testing.T, workspaceMappings []interface{}) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	// the triggers of the test definition are only supported for Git repositories
+	resourceData.Set("ci_trigger", nil)
+	resourceData.Set("pull_request_trigger", nil)
+	resourceData.Set("repository", []interface{}{map[string]interface{}{
+		"repo_name":         "project",
+		"repo_type":         "TfsVersionControl",
+		"branch_name":       "$/project",
+		"workspace_mapping": workspaceMappings,
+	}})
+	return resourceData
+}
+
+// verifies that the workspace mappings of a TFVC repository survive the flatten/expand round trip
+func TestAzureDevOpsBuildDefinition_ExpandFlatten_TfvcRepository(t *testing.T) {
+	resourceData := testTfvcBuildDefinitionResourceData(t, []interface{}{
+		map[string]interface{}{"server_path": "$/project/main", "mapping_type": "map", "local_path": "src"},
+		map[string]interface{}{"server_path": "$/project/main/docs", "mapping_type": "cloak", "local_path": ""},
+	})
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+
+	repository := buildDefinition.Repository
+	require.Equal(t, "TfsVersionControl", *repository.Type)
+	require.Equal(t, "$/", *repository.Id)
+	require.Equal(t, "$/project", *repository.RootFolder)
+	require.Equal(t, "$/project", *repository.DefaultBranch)
+	require.JSONEq(t, `{"mappings": [
+		{"serverPath": "$/project/main", "mappingType": "map", "localPath": "\\src"},
+		{"serverPath": "$/project/main/docs", "mappingType": "cloak", "localPath": ""}
+	]}`, (*repository.Properties)["tfvcMapping"])
+
+	// YAML pipelines only build Git repositories
+	process := buildDefinition.Process.(*designerProcess)
+	require.Equal(t, processTypeDesigner, *process.Type)
+	require.Equal(t, 1, len(*process.Phases))
+
+	err = flattenBuildDefinition(resourceData, buildDefinition, testProjectID)
+	require.Nil(t, err)
+	flattened := resourceData.Get("repository").(*schema.Set).List()[0].(map[string]interface{})
+	require.Equal(t, "", flattened["yml_path"])
+	require.Equal(t, "$/project", flattened["branch_name"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"server_path": "$/project/main", "mapping_type": "map", "local_path": "src"},
+		map[string]interface{}{"server_path": "$/project/main/docs", "mapping_type": "cloak", "local_path": ""},
+	}, flattened["workspace_mapping"])
+}
+
+// verifies that the settings which TFVC repositories do not support are rejected
+func TestAzureDevOpsBuildDefinition_Expand_TfvcRepositoryIsValidated(t *testing.T) {
+	mapping := map[string]interface{}{"server_path": "$/project", "mapping_type": "map", "local_path": ""}
+	cloak := map[string]interface{}{"server_path": "$/project/docs", "mapping_type": "cloak", "local_path": ""}
+	branchFilter := []interface{}{map[string]interface{}{"include": []interface{}{"master"}}}
+
+	cases := []struct {
+		Name          string
+		Repository    map[string]interface{}
+		Settings      map[string]interface{}
+		ExpectedError string
+	}{
+		{"root folder", map[string]interface{}{"branch_name": "master"}, nil, "root server path"},
+		{"yaml", map[string]interface{}{"yml_path": "azure-pipelines.yml"}, nil, "yml_path"},
+		{"mappings", map[string]interface{}{"workspace_mapping": []interface{}{cloak}}, nil, "workspace_mapping of type map"},
+		{"yaml ci trigger", nil, map[string]interface{}{
+			"ci_trigger": []interface{}{map[string]interface{}{"use_yaml": true}},
+		}, "override"},
+		{"branch filter", nil, map[string]interface{}{
+			"ci_trigger": []interface{}{map[string]interface{}{"override": []interface{}{map[string]interface{}{
+				"batch": true, "max_concurrent_builds_per_branch": 1, "branch_filter": branchFilter,
+			}}}},
+		}, "path_filter"},
+		{"path filter", nil, map[string]interface{}{
+			"ci_trigger": []interface{}{map[string]interface{}{"override": []interface{}{map[string]interface{}{
+				"batch": true, "max_concurrent_builds_per_branch": 1,
+				"path_filter": []interface{}{map[string]interface{}{"include": []interface{}{"src"}}},
+			}}}},
+		}, "server path"},
+		{"pull request trigger", nil, map[string]interface{}{
+			"pull_request_trigger": []interface{}{map[string]interface{}{
+				"forks": []interface{}{map[string]interface{}{"enabled": false, "share_secrets": false}},
+			}},
+		}, "pull_request_trigger"},
+	}
+
+	for _, tc := range cases {
+		repository := map[string]interface{}{
+			"repo_name":         "project",
+			"repo_type":         "TfsVersionControl",
+			"branch_name":       "$/project",
+			"workspace_mapping": []interface{}{mapping},
+		}
+		for k, v := range tc.Repository {
+			repository[k] = v
+		}
+
+		resourceData := testTfvcBuildDefinitionResourceData(t, nil)
+		resourceData.Set("repository", []interface{}{repository})
+		for k, v := range tc.Settings {
+			resourceData.Set(k, v)
+		}
+
+		_, _, err := expandBuildDefinition(resourceData)
+		require.NotNil(t, err, tc.Name)
+		require.Contains(t, err.Error(), tc.ExpectedError, tc.Name)
+	}
+}
+
+// verifies that a gated check-in trigger is rejected for Git repositories
+func TestAzureDevOpsBuildDefinition_Expand_GitRepositoryRejectsGatedCheckInTrigger(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("gated_checkin_trigger", []interface{}{map[string]interface{}{"use_workspace_mappings": true}})
+
+	_, _, err := expandBuildDefinition(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "gated_checkin_trigger")
+}
+
+// the value Terraform uses for attributes that are not known at plan time
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// verifies that the settings required by Git repositories are validated at plan time
+func TestAzureDevOpsBuildDefinition_Diff_GitRepositoryRequiresYamlAndBranchFilter(t *testing.T) {
+	overrideWithoutBranchFilter := []interface{}{map[string]interface{}{"override": []interface{}{map[string]interface{}{
+		"path_filter": []interface{}{map[string]interface{}{"include": []interface{}{"src"}}},
+	}}}}
+
+	clients := &config.AggregatedClient{Ctx: context.Background()}
+
+	cases := []struct {
+		Name          string
+		Repository    map[string]interface{}
+		CiTrigger     []interface{}
+		ExpectedError string
+	}{
+		{"missing yml_path", map[string]interface{}{"repo_type": "GitHub"}, nil, "A yml_path is required for repositories of type GitHub"},
+		{"missing branch_filter", map[string]interface{}{"repo_type": "GitHub", "yml_path": "azure-pipelines.yml"}, overrideWithoutBranchFilter, "requires a branch_filter"},
+		{"unknown yml_path", map[string]interface{}{"repo_type": "GitHub", "yml_path": testUnknownValue}, nil, ""},
+		{"tfvc", map[string]interface{}{"repo_type": "TfsVersionControl", "branch_name": "$/project"}, nil, ""},
+	}
+
+	for _, tc := range cases {
+		tc.Repository["repo_name"] = "repoOrg/repoName"
+		rawConfig := map[string]interface{}{
+			"project_id": testProjectID,
+			"repository": []interface{}{tc.Repository},
+		}
+		if tc.CiTrigger != nil {
+			rawConfig["ci_trigger"] = tc.CiTrigger
+		}
+
+		_, err := resourceBuildDefinition().Diff(nil, terraform.NewResourceConfigRaw(rawConfig), clients)
+		if tc.ExpectedError == "" {
+			require.Nil(t, err, tc.Name)
+			continue
+		}
+		require.NotNil(t, err, tc.Name)
+		require.Contains(t, err.Error(), tc.ExpectedError, tc.Name)
+	}
+}
+
 // validates that and error is thrown if any of the un-supported file path characters are used
 func TestAzureDevOpsBuildDefinition_PathInvalidCharacterListIsError(t *testing.T) {
 	expectedInvalidPathCharacters := []string{"<", ">", "|", ":", "$", "@", "\"", "/", "%", "+", "*", "?"}
@@ -88,7 +420,7 @@ func TestAzureDevOpsBuildDefinition_PathInvalidCharacterListIsError(t *testing.T
 // verifies that the flatten/expand round trip yields the same build definition
 func TestAzureDevOpsBuildDefinition_ExpandFlatten_Roundtrip(t *testing.T) {
 	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
-	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	flattenManagedBuildDefinition(resourceData, &testBuildDefinition)
 
 	buildDefinitionAfterRoundTrip, projectID, err := expandBuildDefinition(resourceData)
 
@@ -97,6 +429,428 @@ func TestAzureDevOpsBuildDefinition_ExpandFlatten_Roundtrip(t *testing.T) {
 	require.Equal(t, testProjectID, projectID)
 }
 
+// verifies that a CI trigger read from the service is flattened for drift detection
+func TestAzureDevOpsBuildDefinition_Flatten_CiTriggerFromService(t *testing.T) {
+	buildDefinition := testBuildDefinition
+	buildDefinition.Triggers = &[]interface{}{
+		map[string]interface{}{
+			"branchFilters":                []interface{}{"+master", "-dev"},
+			"pathFilters":                  []interface{}{},
+			"batchChanges":                 false,
+			"maxConcurrentBuildsPerBranch": float64(1),
+			"settingsSourceType":           float64(1),
+			"triggerType":                  "continuousIntegration",
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenBuildDefinition(resourceData, &buildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	require.Equal(t, false, resourceData.Get("ci_trigger.0.use_yaml"))
+	require.Equal(t, false, resourceData.Get("ci_trigger.0.override.0.batch"))
+	require.Equal(t, []interface{}{"master"}, resourceData.Get("ci_trigger.0.override.0.branch_filter.0.include"))
+	require.Equal(t, []interface{}{"dev"}, resourceData.Get("ci_trigger.0.override.0.branch_filter.0.exclude"))
+	require.Equal(t, 0, resourceData.Get("ci_trigger.0.override.0.path_filter.#"))
+}
+
+// verifies that a CI trigger that uses the YAML settings survives the flatten/expand round trip
+func TestAzureDevOpsBuildDefinition_ExpandFlatten_CiTriggerUsesYaml(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("ci_trigger", []interface{}{map[string]interface{}{"use_yaml": true}})
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+
+	trigger := (*buildDefinition.Triggers)[0].(*ciTrigger)
+	require.Equal(t, build.DefinitionTriggerTypeValues.ContinuousIntegration, *trigger.TriggerType)
+	require.Equal(t, triggerSettingsSourceTypeYaml, *trigger.SettingsSourceType)
+
+	err = flattenBuildDefinition(resourceData, buildDefinition, testProjectID)
+	require.Nil(t, err)
+	require.Equal(t, true, resourceData.Get("ci_trigger.0.use_yaml"))
+	require.Equal(t, 0, resourceData.Get("ci_trigger.0.override.#"))
+}
+
+// verifies that the YAML settings and an override of a CI trigger are rejected at plan time
+func TestAzureDevOpsBuildDefinition_Validate_CiTriggerYamlConflictsWithOverride(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": testProjectID,
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+		"ci_trigger": []interface{}{map[string]interface{}{
+			"use_yaml": true,
+			"override": []interface{}{map[string]interface{}{
+				"branch_filter": []interface{}{map[string]interface{}{"include": []interface{}{"master"}}},
+			}},
+		}},
+	})
+
+	_, errors := resourceBuildDefinition().Validate(config)
+	require.Equal(t, 1, len(errors))
+	require.Contains(t, errors[0].Error(), "conflicts with")
+}
+
+// verifies that a CI trigger without YAML settings requires an override
+func TestAzureDevOpsBuildDefinition_Expand_CiTriggerRequiresOverride(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("ci_trigger", []interface{}{map[string]interface{}{"use_yaml": false}})
+
+	_, _, err := expandBuildDefinition(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "override")
+}
+
+// verifies that the comment requirement of a pull request trigger is mapped to the flags AzDO expects
+func TestAzureDevOpsBuildDefinition_ExpandFlatten_PullRequestTriggerCommentRequired(t *testing.T) {
+	cases := []struct {
+		CommentRequired    string
+		IsCommentRequired  bool
+		NonTeamMembersOnly bool
+	}{
+		{"", false, false},
+		{"All", true, false},
+		{"NonTeamMembers", true, true},
+	}
+
+	for _, tc := range cases {
+		resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+		flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+		resourceData.Set("pull_request_trigger", []interface{}{map[string]interface{}{
+			"use_yaml":         true,
+			"comment_required": tc.CommentRequired,
+			"forks": []interface{}{map[string]interface{}{
+				"enabled":       true,
+				"share_secrets": true,
+			}},
+		}})
+
+		buildDefinition, _, err := expandBuildDefinition(resourceData)
+		require.Nil(t, err)
+
+		trigger := (*buildDefinition.Triggers)[1].(*pullRequestTrigger)
+		require.Equal(t, build.DefinitionTriggerTypeValues.PullRequest, *trigger.TriggerType)
+		require.Equal(t, tc.IsCommentRequired, *trigger.IsCommentRequiredForPullRequest)
+		require.Equal(t, tc.NonTeamMembersOnly, *trigger.RequireCommentsForNonTeamMembersOnly)
+		require.Equal(t, true, *trigger.Forks.AllowSecrets)
+
+		err = flattenBuildDefinition(resourceData, buildDefinition, testProjectID)
+		require.Nil(t, err)
+		require.Equal(t, tc.CommentRequired, resourceData.Get("pull_request_trigger.0.comment_required"))
+		require.Equal(t, true, resourceData.Get("pull_request_trigger.0.use_yaml"))
+	}
+}
+
+// verifies that a pull request trigger read from the service is flattened for drift detection
+func TestAzureDevOpsBuildDefinition_Flatten_PullRequestTriggerFromService(t *testing.T) {
+	buildDefinition := testBuildDefinition
+	buildDefinition.Triggers = &[]interface{}{
+		map[string]interface{}{
+			"branchFilters":                   []interface{}{"+master"},
+			"pathFilters":                     []interface{}{"+src/*"},
+			"autoCancel":                      true,
+			"isCommentRequiredForPullRequest": false,
+			"forks": map[string]interface{}{
+				"enabled":      true,
+				"allowSecrets": true,
+			},
+			"settingsSourceType": float64(1),
+			"triggerType":        "pullRequest",
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenBuildDefinition(resourceData, &buildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	require.Equal(t, 0, resourceData.Get("ci_trigger.#"))
+	require.Equal(t, "", resourceData.Get("pull_request_trigger.0.comment_required"))
+	require.Equal(t, true, resourceData.Get("pull_request_trigger.0.forks.0.share_secrets"))
+	require.Equal(t, true, resourceData.Get("pull_request_trigger.0.override.0.auto_cancel"))
+	require.Equal(t, []interface{}{"src/*"}, resourceData.Get("pull_request_trigger.0.override.0.path_filter.0.include"))
+}
+
+// verifies that a schedule read from the service is flattened for drift detection
+func TestAzureDevOpsBuildDefinition_Flatten_ScheduleFromService(t *testing.T) {
+	buildDefinition := testBuildDefinition
+	buildDefinition.Triggers = &[]interface{}{
+		map[string]interface{}{
+			"schedules": []interface{}{
+				map[string]interface{}{
+					"branchFilters":           []interface{}{"+master"},
+					"daysToBuild":             "all",
+					"scheduleJobId":           uuid.New().String(),
+					"scheduleOnlyWithChanges": false,
+					"startHours":              float64(22),
+					"timeZoneId":              "UTC",
+				},
+				map[string]interface{}{
+					"branchFilters": []interface{}{"+releases/*"},
+					"daysToBuild":   "Saturday,Sunday",
+					"startHours":    float64(1),
+					"startMinutes":  float64(15),
+					"timeZoneId":    "UTC",
+				},
+			},
+			"triggerType": "schedule",
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenBuildDefinition(resourceData, &buildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, resourceData.Get("schedule.#"))
+	require.Equal(t, 7, resourceData.Get("schedule.0.days_to_build").(*schema.Set).Len())
+	require.Equal(t, 22, resourceData.Get("schedule.0.start_hours"))
+	require.Equal(t, 0, resourceData.Get("schedule.0.start_minutes"))
+	require.Equal(t, false, resourceData.Get("schedule.0.schedule_only_with_changes"))
+	require.ElementsMatch(t, []interface{}{"Saturday", "Sunday"}, resourceData.Get("schedule.1.days_to_build").(*schema.Set).List())
+	require.Equal(t, []interface{}{"releases/*"}, resourceData.Get("schedule.1.branch_filter.0.include"))
+}
+
+// verifies that build completion triggers read from the service are flattened
+func TestAzureDevOpsBuildDefinition_Flatten_BuildCompletionTriggerFromService(t *testing.T) {
+	buildDefinition := testBuildDefinition
+	buildDefinition.Triggers = &[]interface{}{
+		map[string]interface{}{
+			"branchFilters":           []interface{}{"+master", "-releases/*"},
+			"definition":              map[string]interface{}{"id": float64(42), "name": "upstream", "path": "\\"},
+			"requiresSuccessfulBuild": true,
+			"triggerType":             "buildCompletion",
+		},
+		map[string]interface{}{
+			"branchFilters": []interface{}{"+master"},
+			"definition":    map[string]interface{}{"id": float64(43)},
+			"triggerType":   "buildCompletion",
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenBuildDefinition(resourceData, &buildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, resourceData.Get("build_completion_trigger.#"))
+	require.Equal(t, 42, resourceData.Get("build_completion_trigger.0.definition_id"))
+	require.Equal(t, true, resourceData.Get("build_completion_trigger.0.requires_successful_build"))
+	require.Equal(t, []interface{}{"releases/*"}, resourceData.Get("build_completion_trigger.0.branch_filter.0.exclude"))
+	require.Equal(t, 43, resourceData.Get("build_completion_trigger.1.definition_id"))
+	require.Equal(t, false, resourceData.Get("build_completion_trigger.1.requires_successful_build"))
+}
+
+// verifies that a gated check-in trigger of a TFVC repository survives the flatten/expand round trip
+func TestAzureDevOpsBuildDefinition_ExpandFlatten_GatedCheckInTrigger(t *testing.T) {
+	resourceData := testTfvcBuildDefinitionResourceData(t, []interface{}{
+		map[string]interface{}{"server_path": "$/project", "mapping_type": "map", "local_path": ""},
+	})
+	resourceData.Set("gated_checkin_trigger", []interface{}{map[string]interface{}{
+		"run_continuous_integration": true,
+		"use_workspace_mappings":     false,
+		"path_filter": []interface{}{map[string]interface{}{
+			"include": []interface{}{"$/project/main"},
+			"exclude": []interface{}{"$/project/main/docs"},
+		}},
+	}})
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+
+	// the gated check-in trigger is expanded after the other triggers of the test definition
+	triggers := *buildDefinition.Triggers
+	trigger := triggers[len(triggers)-1].(*gatedCheckInTrigger)
+	require.Equal(t, build.DefinitionTriggerTypeValues.GatedCheckIn, *trigger.TriggerType)
+	require.Equal(t, true, *trigger.RunContinuousIntegration)
+	require.Equal(t, false, *trigger.UseWorkspaceMappings)
+	require.Equal(t, []string{"+$/project/main", "-$/project/main/docs"}, *trigger.PathFilters)
+
+	err = flattenBuildDefinition(resourceData, buildDefinition, testProjectID)
+	require.Nil(t, err)
+	require.Equal(t, true, resourceData.Get("gated_checkin_trigger.0.run_continuous_integration"))
+	require.Equal(t, false, resourceData.Get("gated_checkin_trigger.0.use_workspace_mappings"))
+	require.Equal(t, []interface{}{"$/project/main"}, resourceData.Get("gated_checkin_trigger.0.path_filter.0.include"))
+}
+
+// verifies that an upstream definition which does not exist in the project fails the plan
+func TestAzureDevOpsBuildDefinition_Diff_FailsIfUpstreamDefinitionDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueues(clients.Ctx, gomock.Any()).
+		Return(&[]taskagent.TaskAgentQueue{testAgentQueue}, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: converter.Int(42)}).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":      testProjectID,
+		"agent_pool_name": "BuildPoolName",
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+		"build_completion_trigger": []interface{}{map[string]interface{}{
+			"definition_id": 42,
+			"branch_filter": []interface{}{map[string]interface{}{"include": []interface{}{"master"}}},
+		}},
+	})
+
+	_, err := resourceBuildDefinition().Diff(nil, config, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Build definition 42 of the build_completion_trigger does not exist")
+}
+
+// verifies that a build definition cannot be triggered by itself
+func TestAzureDevOpsBuildDefinition_Diff_FailsIfUpstreamDefinitionIsItself(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"project_id": testProjectID,
+		},
+	}
+	resourceConfig := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": testProjectID,
+		"build_completion_trigger": []interface{}{map[string]interface{}{
+			"definition_id": 42,
+			"branch_filter": []interface{}{map[string]interface{}{"include": []interface{}{"master"}}},
+		}},
+	})
+
+	_, err := resourceBuildDefinition().Diff(state, resourceConfig, &config.AggregatedClient{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "cannot be triggered by the completion of its own builds")
+}
+
+// verifies that invalid schedules are rejected at plan time
+func TestAzureDevOpsBuildDefinition_Validate_ScheduleIsValidated(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": testProjectID,
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+		"schedule": []interface{}{map[string]interface{}{
+			"days_to_build": []interface{}{"Mon"},
+			"start_hours":   24,
+			"start_minutes": 60,
+			"branch_filter": []interface{}{map[string]interface{}{"include": []interface{}{"master"}}},
+		}},
+	})
+
+	_, errors := resourceBuildDefinition().Validate(config)
+	require.Equal(t, 3, len(errors))
+}
+
+// verifies that secret variables are sent to AzDO and only a hash of their value is kept in the state
+func TestAzureDevOpsBuildDefinition_ExpandFlatten_SecretVariableIsHashed(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, map[string]interface{}{
+		"project_id": testProjectID,
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+		"variable": []interface{}{map[string]interface{}{
+			"name":      "secret",
+			"value":     "s3cr3t",
+			"is_secret": true,
+		}},
+	})
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, "s3cr3t", *(*buildDefinition.Variables)["secret"].Value)
+	require.Equal(t, true, *(*buildDefinition.Variables)["secret"].IsSecret)
+
+	// AzDO never returns the values of secret variables
+	buildDefinition.Id = converter.Int(100)
+	buildDefinition.Variables = &map[string]build.BuildDefinitionVariable{
+		"secret": {IsSecret: converter.Bool(true), AllowOverride: converter.Bool(true)},
+	}
+	err = flattenBuildDefinition(resourceData, buildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	variableKey := fmt.Sprintf("variable.%d", getBuildDefinitionVariableHash(map[string]interface{}{"name": "secret"}))
+	require.Equal(t, "", resourceData.Get(variableKey+".value"))
+	isUpdating, _, err := secretmemo.IsUpdating("s3cr3t", resourceData.Get(variableKey+".value_hash").(string))
+	require.Nil(t, err)
+	require.False(t, isUpdating)
+}
+
+// verifies that only changes to the value of a secret variable are compared with the hash of the applied value
+func TestAzureDevOpsBuildDefinition_SuppressSecretVariableChanged(t *testing.T) {
+	_, hash, err := secretmemo.IsUpdating("s3cr3t", "")
+	require.Nil(t, err)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	resourceData.Set("variable", []interface{}{
+		map[string]interface{}{"name": "secret", "value": "", "value_hash": hash, "is_secret": true, "allow_override": true},
+		map[string]interface{}{"name": "plain", "value": "", "value_hash": "", "is_secret": false, "allow_override": true},
+	})
+
+	secretKey := fmt.Sprintf("variable.%d.value", getBuildDefinitionVariableHash(map[string]interface{}{"name": "secret"}))
+	require.True(t, suppressBuildDefinitionSecretVariableChanged(secretKey, "", "s3cr3t", resourceData))
+	require.False(t, suppressBuildDefinitionSecretVariableChanged(secretKey, "", "changed", resourceData))
+
+	plainKey := fmt.Sprintf("variable.%d.value", getBuildDefinitionVariableHash(map[string]interface{}{"name": "plain"}))
+	require.False(t, suppressBuildDefinitionSecretVariableChanged(plainKey, "value", "", resourceData))
+}
+
+// verifies that variables, demands and retention rules which are not configured are neither read nor expanded,
+// so that an update can send back the settings made in AzDO
+func TestAzureDevOpsBuildDefinition_ExpandFlatten_UnconfiguredSettingsAreKept(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	require.Equal(t, 0, resourceData.Get("demands").(*schema.Set).Len())
+	require.Equal(t, 0, resourceData.Get("retention_rule.#"))
+	require.Equal(t, 0, resourceData.Get("variable").(*schema.Set).Len())
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Nil(t, buildDefinition.Demands)
+	require.Nil(t, buildDefinition.RetentionRules)
+	require.Nil(t, buildDefinition.Variables)
+}
+
+// verifies that variables, demands and retention rules which are removed from the configuration are removed in AzDO
+func TestAzureDevOpsBuildDefinition_Expand_RemovedSettingsAreCleared(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenManagedBuildDefinition(resourceData, &testBuildDefinition)
+	require.Nil(t, err)
+
+	resourceData, err = schema.InternalMap(resourceBuildDefinition().Schema).Data(resourceData.State(), &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"demands.#":        {Old: "1", New: "0"},
+			"retention_rule.#": {Old: "1", New: "0"},
+			"variable.#":       {Old: "1", New: "0"},
+		},
+	})
+	require.Nil(t, err)
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, &[]interface{}{}, buildDefinition.Demands)
+	require.Equal(t, &[]build.RetentionPolicy{}, buildDefinition.RetentionRules)
+	require.Equal(t, &map[string]build.BuildDefinitionVariable{}, buildDefinition.Variables)
+}
+
 // verifies that an expand will fail if there is insufficient configuration data found in the resource
 func TestAzureDevOpsBuildDefinition_Expand_FailsIfNotEnoughData(t *testing.T) {
 	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
@@ -104,16 +858,181 @@ func TestAzureDevOpsBuildDefinition_Expand_FailsIfNotEnoughData(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+// verifies that the default agent queue is used if no queue is configured
+func TestAzureDevOpsBuildDefinition_Expand_DefaultAgentQueue(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("agent_pool_name", "")
+	resourceData.Set("agent_queue_id", 0)
+
+	buildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Nil(t, buildDefinition.Queue.Id)
+	require.Equal(t, defaultBuildDefinitionAgentQueueName, *buildDefinition.Queue.Name)
+}
+
+// verifies that a queue referenced by name is resolved to the queue of the project
+func TestAzureDevOpsBuildDefinition_GetAgentQueue_ResolvesName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueues(clients.Ctx, taskagent.GetAgentQueuesArgs{Project: &testProjectID, QueueName: converter.String("buildpoolname")}).
+		Return(&[]taskagent.TaskAgentQueue{testAgentQueue}, nil).
+		Times(1)
+
+	queue, err := getAgentQueue(clients, testProjectID, 0, "buildpoolname")
+	require.Nil(t, err)
+	require.Equal(t, 5, *queue.Id)
+}
+
+// verifies that a queue which does not exist in the project is reported as an error
+func TestAzureDevOpsBuildDefinition_GetAgentQueue_FailsIfQueueDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{Project: &testProjectID, QueueId: converter.Int(10)}).
+		Return(nil, nil).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueues(clients.Ctx, taskagent.GetAgentQueuesArgs{Project: &testProjectID, QueueName: converter.String("missing")}).
+		Return(&[]taskagent.TaskAgentQueue{}, nil).
+		Times(1)
+
+	_, err := getAgentQueue(clients, testProjectID, 10, "")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "does not exist")
+
+	_, err = getAgentQueue(clients, testProjectID, 0, "missing")
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "does not exist")
+}
+
+// verifies that a missing agent queue fails the plan
+func TestAzureDevOpsBuildDefinition_Diff_FailsIfAgentQueueDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueues(clients.Ctx, taskagent.GetAgentQueuesArgs{Project: &testProjectID, QueueName: converter.String("missing")}).
+		Return(&[]taskagent.TaskAgentQueue{}, nil).
+		Times(1)
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":      testProjectID,
+		"agent_pool_name": "missing",
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+	})
+
+	_, err := resourceBuildDefinition().Diff(nil, config, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Agent queue missing does not exist")
+}
+
+// verifies that switching to a reference by ID recomputes the name of the agent queue
+func TestAzureDevOpsBuildDefinition_Diff_AgentQueueIDRecomputesName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{Project: &testProjectID, QueueId: converter.Int(10)}).
+		Return(&taskagent.TaskAgentQueue{Id: converter.Int(10), Name: converter.String("Other")}, nil).
+		Times(1)
+
+	state := &terraform.InstanceState{
+		ID: "100",
+		Attributes: map[string]string{
+			"project_id":      testProjectID,
+			"agent_pool_name": "BuildPoolName",
+			"agent_queue_id":  "5",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":     testProjectID,
+		"agent_queue_id": 10,
+	})
+
+	diff, err := resourceBuildDefinition().Diff(state, config, clients)
+	require.Nil(t, err)
+	require.True(t, diff.Attributes["agent_pool_name"].NewComputed)
+	require.Equal(t, "10", diff.Attributes["agent_queue_id"].New)
+}
+
+// verifies that the agent queue can only be referenced either by name or by ID
+func TestAzureDevOpsBuildDefinition_Validate_AgentQueueNameConflictsWithID(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":      testProjectID,
+		"agent_pool_name": "BuildPoolName",
+		"agent_queue_id":  5,
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+	})
+
+	_, errors := resourceBuildDefinition().Validate(config)
+	require.Equal(t, 2, len(errors))
+	require.Contains(t, errors[0].Error(), "conflicts with")
+}
+
+// verifies that invalid general settings are rejected at plan time
+func TestAzureDevOpsBuildDefinition_Validate_GeneralSettingsAreValidated(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":                    testProjectID,
+		"queue_status":                  "stopped",
+		"job_cancel_timeout_in_minutes": 0,
+		"job_authorization_scope":       "organization",
+		"repository": []interface{}{map[string]interface{}{
+			"yml_path":  "azure-pipelines.yml",
+			"repo_name": "repoOrg/repoName",
+			"repo_type": "GitHub",
+		}},
+	})
+
+	_, errors := resourceBuildDefinition().Validate(config)
+	require.Equal(t, 3, len(errors))
+}
+
 // verifies that if an error is produced on create, the error is not swallowed
 func TestAzureDevOpsBuildDefinition_Create_DoesNotSwallowError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
-	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	flattenManagedBuildDefinition(resourceData, &testBuildDefinition)
 
 	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{Project: &testProjectID, QueueId: testBuildDefinition.Queue.Id}).
+		Return(&testAgentQueue, nil).
+		Times(1)
 
 	expectedArgs := build.CreateDefinitionArgs{Definition: &testBuildDefinition, Project: &testProjectID}
 	buildClient.
@@ -144,57 +1063,481 @@ func TestAzureDevOpsBuildDefinition_Read_DoesNotSwallowError(t *testing.T) {
 		Return(nil, errors.New("GetDefinition() Failed")).
 		Times(1)
 
-	err := resourceBuildDefinitionRead(resourceData, clients)
-	require.Equal(t, "GetDefinition() Failed", err.Error())
-}
+	err := resourceBuildDefinitionRead(resourceData, clients)
+	require.Equal(t, "GetDefinition() Failed", err.Error())
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestAzureDevOpsBuildDefinition_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	expectedArgs := build.DeleteDefinitionArgs{DefinitionId: testBuildDefinition.Id, Project: &testProjectID}
+	buildClient.
+		EXPECT().
+		DeleteDefinition(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteDefinition() Failed")).
+		Times(1)
+
+	err := resourceBuildDefinitionDelete(resourceData, clients)
+	require.Equal(t, "DeleteDefinition() Failed", err.Error())
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestAzureDevOpsBuildDefinition_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenManagedBuildDefinition(resourceData, &testBuildDefinition)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{Project: &testProjectID, QueueId: testBuildDefinition.Queue.Id}).
+		Return(&testAgentQueue, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: testBuildDefinition.Id}).
+		Return(&testBuildDefinition, nil).
+		Times(1)
+
+	expectedArgs := build.UpdateDefinitionArgs{
+		Definition:   &testBuildDefinition,
+		DefinitionId: testBuildDefinition.Id,
+		Project:      &testProjectID,
+	}
+
+	buildClient.
+		EXPECT().
+		UpdateDefinition(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateDefinition() Failed")).
+		Times(1)
+
+	err := resourceBuildDefinitionUpdate(resourceData, clients)
+	require.Equal(t, "UpdateDefinition() Failed", err.Error())
+}
+
+// verifies that the steps of a TFVC build definition, which are managed in AzDO, are kept on an update
+func TestAzureDevOpsBuildDefinition_Update_KeepsDesignerProcessOfTfvcRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := testTfvcBuildDefinitionResourceData(t, []interface{}{
+		map[string]interface{}{"server_path": "$/project", "mapping_type": "map", "local_path": ""},
+	})
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, gomock.Any()).
+		Return(&testAgentQueue, nil).
+		Times(1)
+
+	currentProcess := map[string]interface{}{
+		"type":   float64(processTypeDesigner),
+		"phases": []interface{}{map[string]interface{}{"name": "Agent job 1", "steps": []interface{}{map[string]interface{}{"displayName": "Build"}}}},
+	}
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: testBuildDefinition.Id}).
+		Return(&build.BuildDefinition{Id: testBuildDefinition.Id, Process: currentProcess}, nil).
+		Times(1)
+
+	buildClient.
+		EXPECT().
+		UpdateDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args build.UpdateDefinitionArgs) (*build.BuildDefinition, error) {
+			require.Equal(t, currentProcess, args.Definition.Process)
+			return nil, errors.New("UpdateDefinition() Failed")
+		}).
+		Times(1)
+
+	err := resourceBuildDefinitionUpdate(resourceData, clients)
+	require.Equal(t, "UpdateDefinition() Failed", err.Error())
+}
+
+// verifies that an update sends back the variables, demands and retention rules which are not configured,
+// as the update replaces the whole build definition
+func TestAzureDevOpsBuildDefinition_Update_KeepsUnconfiguredSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, gomock.Any()).
+		Return(&testAgentQueue, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: testBuildDefinition.Id}).
+		Return(&testBuildDefinition, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		UpdateDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args build.UpdateDefinitionArgs) (*build.BuildDefinition, error) {
+			require.Equal(t, testBuildDefinition.Variables, args.Definition.Variables)
+			require.Equal(t, testBuildDefinition.Demands, args.Definition.Demands)
+			require.Equal(t, testBuildDefinition.RetentionRules, args.Definition.RetentionRules)
+			return nil, errors.New("UpdateDefinition() Failed")
+		}).
+		Times(1)
+
+	err := resourceBuildDefinitionUpdate(resourceData, clients)
+	require.Equal(t, "UpdateDefinition() Failed", err.Error())
+}
+
+var testSourceBuildDefinition = build.BuildDefinition{
+	Id:      converter.Int(42),
+	Options: &[]build.BuildOption{{Enabled: converter.Bool(true), Inputs: &map[string]string{"workItemType": "Bug"}}},
+	Variables: &map[string]build.BuildDefinitionVariable{
+		"TemplateVariable": {Value: converter.String("template")},
+	},
+	RetentionRules: &[]build.RetentionPolicy{{DaysToKeep: converter.Int(30)}},
+	Triggers: &[]interface{}{
+		map[string]interface{}{"triggerType": "continuousIntegration", "branchFilters": []interface{}{"+template"}},
+		map[string]interface{}{"triggerType": "schedule", "schedules": []interface{}{}},
+	},
+}
+
+// returns the types of the triggers sent to AzDO, which are either built by the provider or copied
+func getBuildDefinitionTriggerTypes(t *testing.T, buildDefinition *build.BuildDefinition) []build.DefinitionTriggerType {
+	triggerTypes := []build.DefinitionTriggerType{}
+	for _, rawTrigger := range *buildDefinition.Triggers {
+		var trigger buildDefinitionTrigger
+		require.Nil(t, decodeBuildDefinitionTrigger(rawTrigger, &trigger))
+		triggerTypes = append(triggerTypes, *trigger.TriggerType)
+	}
+	return triggerTypes
+}
+
+// verifies that a cloned build definition copies the settings of its source definition that are not configured
+func TestAzureDevOpsBuildDefinition_Create_ClonesSettingsOfSourceDefinition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("source_definition_id", 42)
+	resourceData.Set("variable", nil)
+	resourceData.Set("retention_rule", nil)
+	resourceData.Set("schedule", nil)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, gomock.Any()).
+		Return(&testAgentQueue, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: converter.Int(42)}).
+		Return(&testSourceBuildDefinition, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		CreateDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args build.CreateDefinitionArgs) (*build.BuildDefinition, error) {
+			require.Equal(t, testSourceBuildDefinition.Options, args.Definition.Options)
+			require.Equal(t, testSourceBuildDefinition.Variables, args.Definition.Variables)
+			require.Equal(t, testSourceBuildDefinition.RetentionRules, args.Definition.RetentionRules)
+			// the configured CI trigger overrides the one of the source definition
+			require.Equal(t, []build.DefinitionTriggerType{
+				build.DefinitionTriggerTypeValues.ContinuousIntegration,
+				build.DefinitionTriggerTypeValues.PullRequest,
+				build.DefinitionTriggerTypeValues.BuildCompletion,
+				build.DefinitionTriggerTypeValues.Schedule,
+			}, getBuildDefinitionTriggerTypes(t, args.Definition))
+			require.NotContains(t, *(*args.Definition.Triggers)[0].(*ciTrigger).BranchFilters, "+template")
+			return nil, errors.New("CreateDefinition() Failed")
+		}).
+		Times(1)
+
+	err := resourceBuildDefinitionCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "CreateDefinition() Failed")
+}
+
+// verifies that a source definition which does not exist is reported
+func TestAzureDevOpsBuildDefinition_Create_FailsIfSourceDefinitionDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("source_definition_id", 42)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, gomock.Any()).
+		Return(&testAgentQueue, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := resourceBuildDefinitionCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Build definition 42 to copy the settings from does not exist")
+}
+
+// verifies that only the configured triggers of a cloned build definition are part of the state, so that the
+// triggers copied from its source definition do not show up as changes
+func TestAzureDevOpsBuildDefinition_Flatten_OmitsUnconfiguredCopiedTriggers(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	resourceData.Set("source_definition_id", 42)
+	resourceData.Set("ci_trigger", []interface{}{map[string]interface{}{"use_yaml": true}})
+	err := flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	require.Nil(t, err)
+
+	require.Equal(t, 1, resourceData.Get("ci_trigger.#"))
+	require.Equal(t, 0, resourceData.Get("pull_request_trigger.#"))
+	require.Equal(t, 0, resourceData.Get("schedule.#"))
+	require.Equal(t, 0, resourceData.Get("build_completion_trigger.#"))
+}
+
+// verifies that an update of a cloned build definition sends back its options and the copied triggers which
+// are not configured, as the update replaces the whole build definition
+func TestAzureDevOpsBuildDefinition_Update_KeepsCopiedTriggersAndOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	resourceData.Set("source_definition_id", 42)
+	resourceData.Set("pull_request_trigger", nil)
+	resourceData.Set("schedule", nil)
+	resourceData.Set("build_completion_trigger", nil)
+
+	currentDefinition := testBuildDefinition
+	currentDefinition.Options = testSourceBuildDefinition.Options
+	currentDefinition.Triggers = testSourceBuildDefinition.Triggers
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, gomock.Any()).
+		Return(&testAgentQueue, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: testBuildDefinition.Id}).
+		Return(&currentDefinition, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		UpdateDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args build.UpdateDefinitionArgs) (*build.BuildDefinition, error) {
+			require.Equal(t, testSourceBuildDefinition.Options, args.Definition.Options)
+			// the configured CI trigger overrides the current one
+			require.Equal(t, []build.DefinitionTriggerType{
+				build.DefinitionTriggerTypeValues.ContinuousIntegration,
+				build.DefinitionTriggerTypeValues.Schedule,
+			}, getBuildDefinitionTriggerTypes(t, args.Definition))
+			require.NotContains(t, *(*args.Definition.Triggers)[0].(*ciTrigger).BranchFilters, "+template")
+			return nil, errors.New("UpdateDefinition() Failed")
+		}).
+		Times(1)
+
+	err := resourceBuildDefinitionUpdate(resourceData, clients)
+	require.Equal(t, "UpdateDefinition() Failed", err.Error())
+}
+
+// verifies that the settings are only copied when the build definition is created, so that a copied
+// trigger which is removed from the configuration is removed from the build definition
+func TestAzureDevOpsBuildDefinition_Update_DoesNotCopySettingsAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	err := flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	require.Nil(t, err)
+	resourceData.Set("source_definition_id", 42)
+
+	resourceData, err = schema.InternalMap(resourceBuildDefinition().Schema).Data(resourceData.State(), &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"schedule.#": {Old: "1", New: "0"},
+		},
+	})
+	require.Nil(t, err)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentQueue(clients.Ctx, gomock.Any()).
+		Return(&testAgentQueue, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinition(clients.Ctx, build.GetDefinitionArgs{Project: &testProjectID, DefinitionId: testBuildDefinition.Id}).
+		Return(&testBuildDefinition, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		UpdateDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args build.UpdateDefinitionArgs) (*build.BuildDefinition, error) {
+			require.Equal(t, []build.DefinitionTriggerType{
+				build.DefinitionTriggerTypeValues.ContinuousIntegration,
+				build.DefinitionTriggerTypeValues.PullRequest,
+				build.DefinitionTriggerTypeValues.BuildCompletion,
+			}, getBuildDefinitionTriggerTypes(t, args.Definition))
+			return nil, errors.New("UpdateDefinition() Failed")
+		}).
+		Times(1)
+
+	err = resourceBuildDefinitionUpdate(resourceData, clients)
+	require.Equal(t, "UpdateDefinition() Failed", err.Error())
+}
+
+// verifies that the source definition is only used on creation, so that changing it does not recreate the build definition
+func TestAzureDevOpsBuildDefinition_SuppressSourceDefinitionChangedAfterCreate(t *testing.T) {
+	r := resourceBuildDefinition()
+	require.False(t, r.Schema["source_definition_id"].ForceNew)
+	suppress := r.Schema["source_definition_id"].DiffSuppressFunc
+
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	require.False(t, suppress("source_definition_id", "", "42", resourceData))
+
+	resourceData.SetId("100")
+	require.True(t, suppress("source_definition_id", "42", "43", resourceData))
+	require.True(t, suppress("source_definition_id", "42", "", resourceData))
+}
+
+// verifies that build definitions can be imported by ID, by name and by full path
+func TestAzureDevOpsBuildDefinition_Import_ResolvesDefinition(t *testing.T) {
+	cases := []struct {
+		ImportID     string
+		ExpectedArgs *build.GetDefinitionsArgs
+	}{
+		{
+			ImportID: "project/100",
+		},
+		{
+			ImportID:     "project/Name",
+			ExpectedArgs: &build.GetDefinitionsArgs{Project: &testProjectID, Name: converter.String("Name")},
+		},
+		{
+			ImportID:     `project/folder\sub\Name`,
+			ExpectedArgs: &build.GetDefinitionsArgs{Project: &testProjectID, Name: converter.String("Name"), Path: converter.String(`\folder\sub`)},
+		},
+		{
+			ImportID:     `project/\folder\Name`,
+			ExpectedArgs: &build.GetDefinitionsArgs{Project: &testProjectID, Name: converter.String("Name"), Path: converter.String(`\folder`)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.ImportID, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-// verifies that if an error is produced on a delete, it is not swallowed
-func TestAzureDevOpsBuildDefinition_Delete_DoesNotSwallowError(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+			coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+			buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+			clients := &config.AggregatedClient{CoreClient: coreClient, BuildClient: buildClient, Ctx: context.Background()}
 
-	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
-	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+			projectID := uuid.MustParse(testProjectID)
+			coreClient.
+				EXPECT().
+				GetProject(clients.Ctx, gomock.Any()).
+				Return(&core.TeamProject{Id: &projectID}, nil).
+				Times(1)
 
-	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+			if tc.ExpectedArgs != nil {
+				buildClient.
+					EXPECT().
+					GetDefinitions(clients.Ctx, *tc.ExpectedArgs).
+					Return(&build.GetDefinitionsResponseValue{
+						Value: []build.BuildDefinitionReference{{Id: converter.Int(100)}},
+					}, nil).
+					Times(1)
+			}
 
-	expectedArgs := build.DeleteDefinitionArgs{DefinitionId: testBuildDefinition.Id, Project: &testProjectID}
-	buildClient.
-		EXPECT().
-		DeleteDefinition(clients.Ctx, expectedArgs).
-		Return(errors.New("DeleteDefinition() Failed")).
-		Times(1)
+			resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+			resourceData.SetId(tc.ImportID)
 
-	err := resourceBuildDefinitionDelete(resourceData, clients)
-	require.Equal(t, "DeleteDefinition() Failed", err.Error())
+			result, err := importBuildDefinition(resourceData, clients)
+			require.Nil(t, err)
+			require.Equal(t, 1, len(result))
+			require.Equal(t, "100", result[0].Id())
+			require.Equal(t, testProjectID, result[0].Get("project_id"))
+		})
+	}
 }
 
-// verifies that if an error is produced on an update, it is not swallowed
-func TestAzureDevOpsBuildDefinition_Update_DoesNotSwallowError(t *testing.T) {
+// verifies that an import by name fails if the name is not unique within the project
+func TestAzureDevOpsBuildDefinition_Import_FailsIfNameIsAmbiguous(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
-	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
-
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
 	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
-	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
-
-	expectedArgs := build.UpdateDefinitionArgs{
-		Definition:   &testBuildDefinition,
-		DefinitionId: testBuildDefinition.Id,
-		Project:      &testProjectID,
-	}
+	clients := &config.AggregatedClient{CoreClient: coreClient, BuildClient: buildClient, Ctx: context.Background()}
 
+	projectID := uuid.MustParse(testProjectID)
+	coreClient.
+		EXPECT().
+		GetProject(clients.Ctx, gomock.Any()).
+		Return(&core.TeamProject{Id: &projectID}, nil).
+		Times(1)
 	buildClient.
 		EXPECT().
-		UpdateDefinition(clients.Ctx, expectedArgs).
-		Return(nil, errors.New("UpdateDefinition() Failed")).
+		GetDefinitions(clients.Ctx, gomock.Any()).
+		Return(&build.GetDefinitionsResponseValue{
+			Value: []build.BuildDefinitionReference{{Id: converter.Int(100)}, {Id: converter.Int(101)}},
+		}, nil).
 		Times(1)
 
-	err := resourceBuildDefinitionUpdate(resourceData, clients)
-	require.Equal(t, "UpdateDefinition() Failed", err.Error())
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	resourceData.SetId("project/Name")
+
+	_, err := importBuildDefinition(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Found 2 build definitions")
+}
+
+// verifies that an import fails if the ID does not contain the project
+func TestAzureDevOpsBuildDefinition_Import_FailsIfProjectIsMissing(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildDefinition().Schema, nil)
+	resourceData.SetId("100")
+
+	_, err := importBuildDefinition(resourceData, &config.AggregatedClient{})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unexpected format of ID")
 }
 
 /**
@@ -276,6 +1619,341 @@ func TestAccAzureDevOpsBuildDefinition_CreateAndUpdate(t *testing.T) {
 					resource.TestCheckResourceAttr(tfBuildDefNode, "path", buildDefinitionPathFourth),
 					testAccCheckBuildDefinitionResourceExists(buildDefinitionNameFirst),
 				),
+			}, {
+				ResourceName:      tfBuildDefNode,
+				ImportStateIdFunc: testAccBuildDefinitionImportStateIDFunc(tfBuildDefNode, "id"),
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the configured path is not prefixed with the root folder `\`, the imported path is
+				ImportStateVerifyIgnore: []string{"path"},
+			}, {
+				ResourceName:      tfBuildDefNode,
+				ImportStateIdFunc: testAccBuildDefinitionImportStateIDFunc(tfBuildDefNode, "path"),
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the configured path is not prefixed with the root folder `\`, the imported path is
+				ImportStateVerifyIgnore: []string{"path"},
+			},
+		},
+	})
+}
+
+// returns the ID under which a build definition can be imported, either by its ID or by its full path
+func testAccBuildDefinitionImportStateIDFunc(resourceName string, by string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		attributes := rs.Primary.Attributes
+		if by == "path" {
+			return fmt.Sprintf("%s/%s\\%s", attributes["project_id"], attributes["path"], attributes["name"]), nil
+		}
+		return fmt.Sprintf("%s/%s", attributes["project_id"], rs.Primary.ID), nil
+	}
+}
+
+// validates that the CI trigger of a build definition can be switched between the YAML settings and an override
+func TestAccAzureDevOpsBuildDefinition_CiTrigger(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	useYaml := `
+	ci_trigger {
+		use_yaml = true
+	}`
+	override := `
+	ci_trigger {
+		override {
+			batch                            = false
+			max_concurrent_builds_per_branch = 2
+			branch_filter {
+				include = ["master", "releases/*"]
+				exclude = ["releases/old*"]
+			}
+			path_filter {
+				exclude = ["docs/*"]
+			}
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, useYaml),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.use_yaml", "true"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.override.#", "0"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, override),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.use_yaml", "false"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.override.0.max_concurrent_builds_per_branch", "2"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.override.0.branch_filter.0.include.#", "2"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.override.0.path_filter.0.exclude.0", "docs/*"),
+				),
+			},
+		},
+	})
+}
+
+// validates that the pull request trigger of a build definition, including its fork settings, is applied
+func TestAccAzureDevOpsBuildDefinition_PullRequestTrigger(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	useYaml := `
+	pull_request_trigger {
+		use_yaml = true
+		forks {
+			enabled       = false
+			share_secrets = false
+		}
+	}`
+	override := `
+	pull_request_trigger {
+		comment_required = "NonTeamMembers"
+		forks {
+			enabled       = true
+			share_secrets = false
+		}
+		override {
+			auto_cancel = false
+			branch_filter {
+				include = ["master"]
+			}
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, useYaml),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "pull_request_trigger.0.use_yaml", "true"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "pull_request_trigger.0.forks.0.enabled", "false"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, override),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "pull_request_trigger.0.use_yaml", "false"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "pull_request_trigger.0.comment_required", "NonTeamMembers"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "pull_request_trigger.0.forks.0.enabled", "true"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "pull_request_trigger.0.override.0.auto_cancel", "false"),
+				),
+			},
+		},
+	})
+}
+
+// validates that scheduled triggers of a build definition can be created and updated
+func TestAccAzureDevOpsBuildDefinition_Schedule(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	nightly := `
+	schedule {
+		days_to_build = ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday"]
+		start_hours   = 2
+		time_zone     = "W. Europe Standard Time"
+		branch_filter {
+			include = ["master"]
+		}
+	}`
+	weekly := `
+	schedule {
+		days_to_build              = ["Sunday"]
+		start_hours                = 23
+		start_minutes              = 30
+		schedule_only_with_changes = false
+		branch_filter {
+			include = ["master", "releases/*"]
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, nightly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.#", "1"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.0.days_to_build.#", "5"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.0.start_hours", "2"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.0.time_zone", "W. Europe Standard Time"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, weekly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.0.days_to_build.#", "1"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.0.start_minutes", "30"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.0.schedule_only_with_changes", "false"),
+				),
+			},
+		},
+	})
+}
+
+// validates that variables of a build definition, including secrets, are applied without a perpetual diff
+func TestAccAzureDevOpsBuildDefinition_Variables(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	variables := func(secret string) string {
+		return fmt.Sprintf(`
+	variable {
+		name  = "plain"
+		value = "value"
+	}
+	variable {
+		name           = "secret"
+		value          = "%s"
+		is_secret      = true
+		allow_override = false
+	}`, secret)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, variables("first")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "variable.#", "2"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, variables("second")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "variable.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// validates that the general settings of a build definition can be updated
+func TestAccAzureDevOpsBuildDefinition_GeneralSettings(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	settings := `
+	queue_status                  = "paused"
+	build_number_format           = "$(date:yyyyMMdd)$(rev:.r)"
+	job_timeout_in_minutes        = 30
+	job_cancel_timeout_in_minutes = 10
+	job_authorization_scope       = "project"
+	badge_enabled                 = true
+	demands                       = ["java"]`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "queue_status", "enabled"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "job_authorization_scope", "projectCollection"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, settings),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "queue_status", "paused"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "job_timeout_in_minutes", "30"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "job_cancel_timeout_in_minutes", "10"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "job_authorization_scope", "project"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "badge_enabled", "true"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "demands.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// validates that a build definition can be triggered by the completion of another build definition
+func TestAccAzureDevOpsBuildDefinition_BuildCompletionTrigger(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	upstreamName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithCompletionTrigger(projectName, upstreamName, buildDefinitionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "build_completion_trigger.#", "1"),
+					resource.TestCheckResourceAttrPair(tfBuildDefNode, "build_completion_trigger.0.definition_id", "azuredevops_build_definition.upstream", "id"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "build_completion_trigger.0.branch_filter.0.include.0", "master"),
+				),
+			},
+		},
+	})
+}
+
+// validates that retention rules of a build definition are applied in the configured order
+func TestAccAzureDevOpsBuildDefinition_RetentionRules(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	retentionRules := `
+	retention_rule {
+		branch_filter {
+			include = ["refs/heads/master"]
+		}
+		days_to_keep             = 30
+		minimum_to_keep          = 5
+		artifact_types_to_delete = ["FilePath", "SymbolStore"]
+	}
+	retention_rule {
+		branch_filter {
+			include = ["refs/heads/*"]
+		}
+		days_to_keep        = 5
+		delete_test_results = false
+	}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, retentionRules),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "retention_rule.#", "2"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "retention_rule.0.days_to_keep", "30"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "retention_rule.0.artifact_types_to_delete.#", "2"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "retention_rule.1.branch_filter.0.include.0", "refs/heads/*"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "retention_rule.1.delete_test_results", "false"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBuildDefNode, "retention_rule.#", "0"),
+				),
 			},
 		},
 	})
@@ -305,6 +1983,32 @@ func testAccCheckBuildDefinitionResourceExists(expectedName string) resource.Tes
 
 // verifies that all build definitions referenced in the state are destroyed. This will be invoked
 // *after* terrafform destroys the resource but *before* the state is wiped clean.
+// verifies that the build definition in AzDO has a trigger of the given type, even if it is not part of the state
+func testAccCheckBuildDefinitionHasTrigger(triggerType build.DefinitionTriggerType) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		buildDef, ok := s.RootModule().Resources["azuredevops_build_definition.build"]
+		if !ok {
+			return fmt.Errorf("Did not find a build definition in the TF state")
+		}
+
+		buildDefinition, err := getBuildDefinitionFromResource(buildDef)
+		if err != nil {
+			return err
+		}
+
+		for _, rawTrigger := range *buildDefinition.Triggers {
+			var trigger buildDefinitionTrigger
+			if err := decodeBuildDefinitionTrigger(rawTrigger, &trigger); err != nil {
+				return err
+			}
+			if trigger.TriggerType != nil && *trigger.TriggerType == triggerType {
+				return nil
+			}
+		}
+		return fmt.Errorf("Build Definition %s has no trigger of type %s", *buildDefinition.Name, triggerType)
+	}
+}
+
 func testAccBuildDefinitionCheckDestroy(s *terraform.State) error {
 	for _, resource := range s.RootModule().Resources {
 		if resource.Type != "azuredevops_build_definition" {
@@ -335,6 +2039,74 @@ func getBuildDefinitionFromResource(resource *terraform.ResourceState) (*build.B
 	})
 }
 
+// validates that a build definition can build the TFVC repository of a project
+func TestAccAzureDevOpsBuildDefinition_TfvcRepository(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceTfvc(projectName, buildDefinitionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBuildDefinitionResourceExists(buildDefinitionName),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.override.0.path_filter.0.include.#", "1"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "gated_checkin_trigger.0.use_workspace_mappings", "true"),
+				),
+			}, {
+				ResourceName:      tfBuildDefNode,
+				ImportStateIdFunc: testAccBuildDefinitionImportStateIDFunc(tfBuildDefNode, "id"),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// validates that a build definition can be cloned from a template build definition, keeping the copied variables
+// which are not configured out of the plan
+func TestAccAzureDevOpsBuildDefinition_CloneFromSourceDefinition(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	templateName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildDefNode := "azuredevops_build_definition.build"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildDefinitionCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildDefinitionResourceCloned(projectName, templateName, buildDefinitionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBuildDefinitionResourceExists(buildDefinitionName),
+					resource.TestCheckResourceAttrPair(tfBuildDefNode, "source_definition_id", "azuredevops_build_definition.template", "id"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "ci_trigger.0.use_yaml", "false"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "variable.#", "0"),
+					resource.TestCheckResourceAttr(tfBuildDefNode, "schedule.#", "0"),
+					testAccCheckBuildDefinitionHasTrigger(build.DefinitionTriggerTypeValues.Schedule),
+				),
+			}, {
+				// the copied schedule, which is not configured, does not show up as a change
+				Config:             testhelper.TestAccBuildDefinitionResourceCloned(projectName, templateName, buildDefinitionName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			}, {
+				// an update keeps the copied schedule
+				Config: testhelper.TestAccBuildDefinitionResourceCloned(projectName, templateName, buildDefinitionName+"-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBuildDefinitionResourceExists(buildDefinitionName+"-renamed"),
+					testAccCheckBuildDefinitionHasTrigger(build.DefinitionTriggerTypeValues.Schedule),
+				),
+			},
+		},
+	})
+}
+
 func init() {
 	InitProvider()
 }
@@ -0,0 +1,210 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func resourceBuildFolder() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildFolderCreate,
+		Read:   resourceBuildFolderRead,
+		Update: resourceBuildFolderUpdate,
+		Delete: resourceBuildFolderDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBuildFolderImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateBuildFolderPath,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return isBuildDefinitionPathEqual(old, new)
+				},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+		},
+	}
+}
+
+// the root folder always exists and cannot be managed
+func validateBuildFolderPath(i interface{}, k string) ([]string, []error) {
+	if warnings, errors := validate.FilePath(i, k); len(errors) > 0 {
+		return warnings, errors
+	}
+
+	if strings.Trim(i.(string), "\\") == "" {
+		return nil, []error{fmt.Errorf("%q must not be empty or the root folder", k)}
+	}
+	return nil, nil
+}
+
+func resourceBuildFolderCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	path := normalizeBuildFolderPath(d.Get("path").(string))
+
+	_, err := clients.BuildClient.CreateFolder(clients.Ctx, build.CreateFolderArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(path),
+		Folder: &build.Folder{
+			Path:        converter.String(path),
+			Description: converter.String(d.Get("description").(string)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating build folder %s in project %s: %+v", path, projectID, err)
+	}
+
+	d.SetId(formatBuildFolderID(projectID, path))
+	return resourceBuildFolderRead(d, m)
+}
+
+func resourceBuildFolderRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID, path, err := parseBuildFolderID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	folder, err := getBuildFolder(clients, projectID, path)
+	if err != nil {
+		return fmt.Errorf("Error looking up build folder %s in project %s: %+v", path, projectID, err)
+	}
+
+	if folder == nil {
+		d.SetId("")
+		return nil
+	}
+
+	flattenBuildFolder(d, projectID, folder)
+	return nil
+}
+
+// the folders API returns the folder and all of its sub folders, so the folder itself is picked by its path
+func getBuildFolder(clients *config.AggregatedClient, projectID string, path string) (*build.Folder, error) {
+	folders, err := clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(path),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if folders != nil {
+		for _, folder := range *folders {
+			if isBuildDefinitionPathEqual(converter.ToString(folder.Path, ""), path) {
+				return &folder, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// a changed path renames the folder, which keeps the build definitions stored in it
+func resourceBuildFolderUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID, path, err := parseBuildFolderID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	newPath := normalizeBuildFolderPath(d.Get("path").(string))
+	_, err = clients.BuildClient.UpdateFolder(clients.Ctx, build.UpdateFolderArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(path),
+		Folder: &build.Folder{
+			Path:        converter.String(newPath),
+			Description: converter.String(d.Get("description").(string)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating build folder %s in project %s: %+v", path, projectID, err)
+	}
+
+	d.SetId(formatBuildFolderID(projectID, newPath))
+	return resourceBuildFolderRead(d, m)
+}
+
+func resourceBuildFolderDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID, path, err := parseBuildFolderID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = clients.BuildClient.DeleteFolder(clients.Ctx, build.DeleteFolderArgs{
+		Project: converter.String(projectID),
+		Path:    converter.String(path),
+	})
+	if err != nil {
+		return fmt.Errorf("Error deleting build folder %s in project %s: %+v", path, projectID, err)
+	}
+
+	return nil
+}
+
+func resourceBuildFolderImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// d.Id() here is the last argument passed to the `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
+	clients := m.(*config.AggregatedClient)
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || strings.Trim(parts[1], "\\") == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project/\\path", d.Id())
+	}
+
+	project, err := projectRead(clients, parts[0], parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up project %s: %+v", parts[0], err)
+	}
+
+	d.SetId(formatBuildFolderID(project.Id.String(), normalizeBuildFolderPath(parts[1])))
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenBuildFolder(d *schema.ResourceData, projectID string, folder *build.Folder) {
+	d.Set("project_id", projectID)
+	// the path is kept as configured if it is equivalent, e.g. with or without a leading `\`
+	if !isBuildDefinitionPathEqual(d.Get("path").(string), converter.ToString(folder.Path, "")) {
+		d.Set("path", converter.ToString(folder.Path, ""))
+	}
+	d.Set("description", converter.ToString(folder.Description, ""))
+}
+
+func normalizeBuildFolderPath(path string) string {
+	return "\\" + strings.Trim(path, "\\")
+}
+
+// folder paths use `\` as separator, so the ID is unambiguous
+func formatBuildFolderID(projectID string, path string) string {
+	return projectID + "/" + path
+}
+
+func parseBuildFolderID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected projectId/\\path", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,264 @@
+// +build all resource_build_folder
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuildFolderProjectID = uuid.New()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the root folder cannot be managed
+func TestBuildFolder_Validate_PathIsNotRoot(t *testing.T) {
+	for _, path := range []string{"", `\`, `\\`} {
+		_, errors := validateBuildFolderPath(path, "path")
+		require.Equal(t, 1, len(errors), "path %q", path)
+	}
+
+	_, errors := validateBuildFolderPath(`\folder\sub`, "path")
+	require.Equal(t, 0, len(errors))
+}
+
+// verifies that the path of a created folder is normalized and that errors are not swallowed
+func TestBuildFolder_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildFolder().Schema, nil)
+	resourceData.Set("project_id", testBuildFolderProjectID.String())
+	resourceData.Set("path", `folder\sub\`)
+	resourceData.Set("description", "description")
+
+	expectedArgs := build.CreateFolderArgs{
+		Project: converter.String(testBuildFolderProjectID.String()),
+		Path:    converter.String(`\folder\sub`),
+		Folder: &build.Folder{
+			Path:        converter.String(`\folder\sub`),
+			Description: converter.String("description"),
+		},
+	}
+	buildClient.
+		EXPECT().
+		CreateFolder(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("CreateFolder() Failed")).
+		Times(1)
+
+	err := resourceBuildFolderCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "CreateFolder() Failed")
+}
+
+// verifies that the folder is picked from the folders returned for its path
+func TestBuildFolder_Read_PicksFolderByPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, build.GetFoldersArgs{
+			Project: converter.String(testBuildFolderProjectID.String()),
+			Path:    converter.String(`\folder`),
+		}).
+		Return(&[]build.Folder{
+			{Path: converter.String(`\folder\sub`), Description: converter.String("sub folder")},
+			{Path: converter.String(`\Folder`), Description: converter.String("folder")},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildFolder().Schema, nil)
+	resourceData.SetId(formatBuildFolderID(testBuildFolderProjectID.String(), `\folder`))
+
+	err := resourceBuildFolderRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testBuildFolderProjectID.String(), resourceData.Get("project_id"))
+	require.Equal(t, `\Folder`, resourceData.Get("path"))
+	require.Equal(t, "folder", resourceData.Get("description"))
+}
+
+// verifies that a folder which no longer exists is removed from the state
+func TestBuildFolder_Read_ClearsIDIfFolderDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, gomock.Any()).
+		Return(&[]build.Folder{}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildFolder().Schema, nil)
+	resourceData.SetId(formatBuildFolderID(testBuildFolderProjectID.String(), `\folder`))
+
+	err := resourceBuildFolderRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a changed path renames the folder
+func TestBuildFolder_Update_RenamesFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildFolder().Schema, nil)
+	resourceData.SetId(formatBuildFolderID(testBuildFolderProjectID.String(), `\folder`))
+	resourceData.Set("project_id", testBuildFolderProjectID.String())
+	resourceData.Set("path", `\renamed`)
+
+	expectedArgs := build.UpdateFolderArgs{
+		Project: converter.String(testBuildFolderProjectID.String()),
+		Path:    converter.String(`\folder`),
+		Folder: &build.Folder{
+			Path:        converter.String(`\renamed`),
+			Description: converter.String(""),
+		},
+	}
+	buildClient.
+		EXPECT().
+		UpdateFolder(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateFolder() Failed")).
+		Times(1)
+
+	err := resourceBuildFolderUpdate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateFolder() Failed")
+}
+
+// verifies that a folder is imported using the project and its path
+func TestBuildFolder_Import_ResolvesProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &config.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}
+
+	coreClient.
+		EXPECT().
+		GetProject(clients.Ctx, gomock.Any()).
+		Return(&core.TeamProject{Id: &testBuildFolderProjectID}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildFolder().Schema, nil)
+	resourceData.SetId(`project/folder\sub`)
+
+	result, err := resourceBuildFolderImport(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(result))
+	require.Equal(t, formatBuildFolderID(testBuildFolderProjectID.String(), `\folder\sub`), result[0].Id())
+}
+
+// verifies that an import without a path fails
+func TestBuildFolder_Import_RequiresPath(t *testing.T) {
+	for _, id := range []string{"project", `project/\`, `/\folder`} {
+		resourceData := schema.TestResourceDataRaw(t, resourceBuildFolder().Schema, nil)
+		resourceData.SetId(id)
+
+		_, err := resourceBuildFolderImport(resourceData, &config.AggregatedClient{})
+		require.NotNil(t, err, "ID %q", id)
+	}
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// validates that a folder can be created, renamed and imported
+func TestAccBuildFolder_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	folderName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfFolderNode := "azuredevops_build_folder.folder"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBuildFolderCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildFolderResource(projectName, `\`+folderName, "description"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfFolderNode, "project_id"),
+					resource.TestCheckResourceAttr(tfFolderNode, "path", `\`+folderName),
+					resource.TestCheckResourceAttr(tfFolderNode, "description", "description"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildFolderResource(projectName, `\`+folderName+`-renamed`, "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfFolderNode, "path", `\`+folderName+`-renamed`),
+					resource.TestCheckResourceAttr(tfFolderNode, "description", "updated"),
+				),
+			}, {
+				ResourceName:      tfFolderNode,
+				ImportState:       true,
+				ImportStateIdFunc: testAccBuildFolderImportStateIDFunc(tfFolderNode),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccBuildFolderImportStateIDFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		res, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Did not find a build folder in the TF state")
+		}
+		return res.Primary.Attributes["project_id"] + "/" + res.Primary.Attributes["path"], nil
+	}
+}
+
+func testAccBuildFolderCheckDestroy(s *terraform.State) error {
+	clients := testAccProvider.Meta().(*config.AggregatedClient)
+
+	// verify that every folder referenced in the state does not exist in AzDO
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "azuredevops_build_folder" {
+			continue
+		}
+
+		projectID, path, err := parseBuildFolderID(resource.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		// the project is destroyed as well, so a failed lookup is fine here
+		if folder, err := getBuildFolder(clients, projectID, path); err == nil && folder != nil {
+			return fmt.Errorf("build folder %s should not exist", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,165 @@
+package azuredevops
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// The retention settings always exist for a project, so creating the resource takes over the
+// settings and destroying it only removes them from the state.
+func resourceBuildRetentionSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildRetentionSettingsCreateOrUpdate,
+		Read:   resourceBuildRetentionSettingsRead,
+		Update: resourceBuildRetentionSettingsCreateOrUpdate,
+		Delete: resourceBuildRetentionSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBuildRetentionSettingsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"default_retention_policy": genBuildRetentionPolicySchema(true),
+			"maximum_retention_policy": genBuildRetentionPolicySchema(false),
+			"days_to_keep_deleted_builds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func genBuildRetentionPolicySchema(required bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: required,
+		Optional: !required,
+		Computed: !required,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"days_to_keep": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"minimum_to_keep": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func resourceBuildRetentionSettingsCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	// settings which are not managed by the resource are kept as they are
+	settings, err := clients.BuildClient.GetBuildSettings(clients.Ctx, build.GetBuildSettingsArgs{
+		Project: converter.String(projectID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error looking up retention settings of project %s: %+v", projectID, err)
+	}
+
+	expandBuildRetentionSettings(d, settings)
+	_, err = clients.BuildClient.UpdateBuildSettings(clients.Ctx, build.UpdateBuildSettingsArgs{
+		Project:  converter.String(projectID),
+		Settings: settings,
+	})
+	if err != nil {
+		return fmt.Errorf("Error updating retention settings of project %s: %+v", projectID, err)
+	}
+
+	d.SetId(projectID)
+	return resourceBuildRetentionSettingsRead(d, m)
+}
+
+func resourceBuildRetentionSettingsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Id()
+
+	settings, err := clients.BuildClient.GetBuildSettings(clients.Ctx, build.GetBuildSettingsArgs{
+		Project: converter.String(projectID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error looking up retention settings of project %s: %+v", projectID, err)
+	}
+
+	flattenBuildRetentionSettings(d, projectID, settings)
+	return nil
+}
+
+func resourceBuildRetentionSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourceBuildRetentionSettingsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// d.Id() here is the last argument passed to the `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
+	clients := m.(*config.AggregatedClient)
+	project, err := projectRead(clients, d.Id(), d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error looking up project %s: %+v", d.Id(), err)
+	}
+
+	d.SetId(project.Id.String())
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandBuildRetentionSettings(d *schema.ResourceData, settings *build.BuildSettings) {
+	settings.DefaultRetentionPolicy = expandBuildRetentionPolicy(d.Get("default_retention_policy").([]interface{}), settings.DefaultRetentionPolicy)
+	settings.MaximumRetentionPolicy = expandBuildRetentionPolicy(d.Get("maximum_retention_policy").([]interface{}), settings.MaximumRetentionPolicy)
+
+	if daysToKeep, ok := d.GetOk("days_to_keep_deleted_builds"); ok {
+		settings.DaysToKeepDeletedBuildsBeforeDestroy = converter.Int(daysToKeep.(int))
+	}
+}
+
+func expandBuildRetentionPolicy(rawPolicy []interface{}, policy *build.RetentionPolicy) *build.RetentionPolicy {
+	if len(rawPolicy) != 1 || rawPolicy[0] == nil {
+		return policy
+	}
+
+	if policy == nil {
+		policy = &build.RetentionPolicy{}
+	}
+	values := rawPolicy[0].(map[string]interface{})
+	policy.DaysToKeep = converter.Int(values["days_to_keep"].(int))
+	policy.MinimumToKeep = converter.Int(values["minimum_to_keep"].(int))
+	return policy
+}
+
+func flattenBuildRetentionSettings(d *schema.ResourceData, projectID string, settings *build.BuildSettings) {
+	d.Set("project_id", projectID)
+	d.Set("default_retention_policy", flattenBuildRetentionPolicy(settings.DefaultRetentionPolicy))
+	d.Set("maximum_retention_policy", flattenBuildRetentionPolicy(settings.MaximumRetentionPolicy))
+	d.Set("days_to_keep_deleted_builds", converter.ToInt(settings.DaysToKeepDeletedBuildsBeforeDestroy, 0))
+}
+
+func flattenBuildRetentionPolicy(policy *build.RetentionPolicy) []interface{} {
+	if policy == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"days_to_keep":    converter.ToInt(policy.DaysToKeep, 0),
+		"minimum_to_keep": converter.ToInt(policy.MinimumToKeep, 0),
+	}}
+}
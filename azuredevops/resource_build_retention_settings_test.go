@@ -0,0 +1,179 @@
+// +build all resource_build_retention_settings
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetentionProjectID = uuid.New()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that settings which are not managed by the resource are sent back unchanged
+func TestBuildRetentionSettings_Update_KeepsUnmanagedSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRetentionSettings().Schema, nil)
+	resourceData.Set("project_id", testRetentionProjectID.String())
+	resourceData.Set("default_retention_policy", []interface{}{map[string]interface{}{
+		"days_to_keep":    20,
+		"minimum_to_keep": 3,
+	}})
+
+	project := converter.String(testRetentionProjectID.String())
+	buildClient.
+		EXPECT().
+		GetBuildSettings(clients.Ctx, build.GetBuildSettingsArgs{Project: project}).
+		Return(&build.BuildSettings{
+			DaysToKeepDeletedBuildsBeforeDestroy: converter.Int(30),
+			DefaultRetentionPolicy: &build.RetentionPolicy{
+				DaysToKeep:        converter.Int(10),
+				MinimumToKeep:     converter.Int(1),
+				DeleteTestResults: converter.Bool(true),
+			},
+			MaximumRetentionPolicy: &build.RetentionPolicy{
+				DaysToKeep:    converter.Int(30),
+				MinimumToKeep: converter.Int(10),
+			},
+		}, nil).
+		Times(1)
+
+	expectedArgs := build.UpdateBuildSettingsArgs{
+		Project: project,
+		Settings: &build.BuildSettings{
+			DaysToKeepDeletedBuildsBeforeDestroy: converter.Int(30),
+			DefaultRetentionPolicy: &build.RetentionPolicy{
+				DaysToKeep:        converter.Int(20),
+				MinimumToKeep:     converter.Int(3),
+				DeleteTestResults: converter.Bool(true),
+			},
+			MaximumRetentionPolicy: &build.RetentionPolicy{
+				DaysToKeep:    converter.Int(30),
+				MinimumToKeep: converter.Int(10),
+			},
+		},
+	}
+	buildClient.
+		EXPECT().
+		UpdateBuildSettings(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateBuildSettings() Failed")).
+		Times(1)
+
+	err := resourceBuildRetentionSettingsCreateOrUpdate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateBuildSettings() Failed")
+}
+
+// verifies that the settings are read from the project, so that drift is detected
+func TestBuildRetentionSettings_Read_FlattensSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuildSettings(clients.Ctx, build.GetBuildSettingsArgs{Project: converter.String(testRetentionProjectID.String())}).
+		Return(&build.BuildSettings{
+			DaysToKeepDeletedBuildsBeforeDestroy: converter.Int(30),
+			DefaultRetentionPolicy:               &build.RetentionPolicy{DaysToKeep: converter.Int(15), MinimumToKeep: converter.Int(2)},
+			MaximumRetentionPolicy:               &build.RetentionPolicy{DaysToKeep: converter.Int(30), MinimumToKeep: converter.Int(10)},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRetentionSettings().Schema, nil)
+	resourceData.SetId(testRetentionProjectID.String())
+
+	err := resourceBuildRetentionSettingsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testRetentionProjectID.String(), resourceData.Get("project_id"))
+	require.Equal(t, 15, resourceData.Get("default_retention_policy.0.days_to_keep"))
+	require.Equal(t, 2, resourceData.Get("default_retention_policy.0.minimum_to_keep"))
+	require.Equal(t, 30, resourceData.Get("maximum_retention_policy.0.days_to_keep"))
+	require.Equal(t, 30, resourceData.Get("days_to_keep_deleted_builds"))
+}
+
+// verifies that the settings can be imported using the name of the project
+func TestBuildRetentionSettings_Import_ResolvesProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &config.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}
+
+	coreClient.
+		EXPECT().
+		GetProject(clients.Ctx, gomock.Any()).
+		Return(&core.TeamProject{Id: &testRetentionProjectID}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRetentionSettings().Schema, nil)
+	resourceData.SetId("project")
+
+	result, err := resourceBuildRetentionSettingsImport(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(result))
+	require.Equal(t, testRetentionProjectID.String(), result[0].Id())
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// validates that the retention settings of a project can be updated and imported
+func TestAccBuildRetentionSettings_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfRetentionNode := "azuredevops_build_retention_settings.retention"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildRetentionSettingsResource(projectName, 20, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfRetentionNode, "project_id"),
+					resource.TestCheckResourceAttr(tfRetentionNode, "default_retention_policy.0.days_to_keep", "20"),
+					resource.TestCheckResourceAttr(tfRetentionNode, "default_retention_policy.0.minimum_to_keep", "2"),
+					resource.TestCheckResourceAttrSet(tfRetentionNode, "maximum_retention_policy.0.days_to_keep"),
+				),
+			}, {
+				Config: testhelper.TestAccBuildRetentionSettingsResource(projectName, 25, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfRetentionNode, "default_retention_policy.0.days_to_keep", "25"),
+					resource.TestCheckResourceAttr(tfRetentionNode, "default_retention_policy.0.minimum_to_keep", "3"),
+				),
+			}, {
+				ResourceName:      tfRetentionNode,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
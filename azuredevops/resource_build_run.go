@@ -0,0 +1,258 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+var buildRunPollInterval = 10 * time.Second
+
+func resourceBuildRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildRunCreate,
+		Read:   resourceBuildRunRead,
+		Update: resourceBuildRunUpdate,
+		Delete: resourceBuildRunDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"branch_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_completion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"build_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"build_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBuildRunCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	buildRun, err := expandBuildRun(d)
+	if err != nil {
+		return err
+	}
+
+	queuedBuild, err := clients.BuildClient.QueueBuild(clients.Ctx, build.QueueBuildArgs{
+		Build:   buildRun,
+		Project: converter.String(projectID),
+	})
+	if err != nil {
+		return fmt.Errorf("Error queueing a build of build definition %d in project %s: %+v", *buildRun.Definition.Id, projectID, err)
+	}
+
+	// the ID is set before waiting, so that a failed or timed out build is tainted and queued again by the next apply
+	d.SetId(strconv.Itoa(*queuedBuild.Id))
+	flattenBuildRun(d, queuedBuild)
+
+	if d.Get("wait_for_completion").(bool) {
+		timeout := time.Duration(d.Get("wait_timeout_in_minutes").(int)) * time.Minute
+		completedBuild, err := waitForBuildCompletion(clients, projectID, *queuedBuild.Id, timeout)
+		if err != nil {
+			return err
+		}
+		flattenBuildRun(d, completedBuild)
+
+		if completedBuild.Result == nil || *completedBuild.Result != build.BuildResultValues.Succeeded {
+			return fmt.Errorf("Build %d of build definition %d did not succeed, result: %s", *queuedBuild.Id, *buildRun.Definition.Id, d.Get("result").(string))
+		}
+		return nil
+	}
+
+	return resourceBuildRunRead(d, m)
+}
+
+func waitForBuildCompletion(clients *config.AggregatedClient, projectID string, buildID int, timeout time.Duration) (*build.Build, error) {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(buildRunPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			buildRun, err := clients.BuildClient.GetBuild(clients.Ctx, build.GetBuildArgs{
+				Project: converter.String(projectID),
+				BuildId: converter.Int(buildID),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Error looking up build %d in project %s: %+v", buildID, projectID, err)
+			}
+
+			if buildRun.Status != nil && *buildRun.Status == build.BuildStatusValues.Completed {
+				return buildRun, nil
+			}
+		case <-deadline:
+			return nil, fmt.Errorf("Build %d in project %s did not complete within %s", buildID, projectID, timeout.String())
+		}
+	}
+}
+
+func resourceBuildRunRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	buildID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing the build ID from the Terraform resource data: %v", err)
+	}
+
+	buildRun, err := clients.BuildClient.GetBuild(clients.Ctx, build.GetBuildArgs{
+		Project: converter.String(projectID),
+		BuildId: converter.Int(buildID),
+	})
+	if err != nil {
+		// builds are removed by the retention policies, which must not queue a new build
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[DEBUG] plugin.terraform-provider-azuredevops: Build [%d] in project [%s] no longer exists", buildID, projectID)
+			return nil
+		}
+		return fmt.Errorf("Error looking up build %d in project %s: %+v", buildID, projectID, err)
+	}
+
+	flattenBuildRun(d, buildRun)
+	return nil
+}
+
+// only the settings of the wait can change in place, they have no effect on a build which is already queued
+func resourceBuildRunUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceBuildRunRead(d, m)
+}
+
+// builds are kept in the history of the build definition, deleting the resource only removes it from the state
+func resourceBuildRunDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// the parameters and the variables are both sent as the parameters dictionary of the queued build, which holds the
+// queue time variables of the build. The SDK does not support the template parameters of YAML pipelines, so the
+// parameters are queue time variables as well. As a name can only be set once, a name set as parameter and as
+// variable is rejected instead of silently preferring one of the values.
+func expandBuildRun(d *schema.ResourceData) (*build.Build, error) {
+	buildRun := build.Build{
+		Definition: &build.DefinitionReference{
+			Id: converter.Int(d.Get("definition_id").(int)),
+		},
+	}
+
+	if branchName, ok := d.GetOk("branch_name"); ok {
+		buildRun.SourceBranch = converter.String(branchName.(string))
+	}
+
+	parameters := map[string]string{}
+	for name, value := range d.Get("parameters").(map[string]interface{}) {
+		parameters[name] = value.(string)
+	}
+	for name, value := range d.Get("variables").(map[string]interface{}) {
+		if _, ok := parameters[name]; ok {
+			return nil, fmt.Errorf("%s is set as parameter and as variable of the build run", name)
+		}
+		parameters[name] = value.(string)
+	}
+
+	if len(parameters) > 0 {
+		parametersJSON, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, fmt.Errorf("Error converting the parameters of the build run: %+v", err)
+		}
+		buildRun.Parameters = converter.String(string(parametersJSON))
+	}
+
+	return &buildRun, nil
+}
+
+func flattenBuildRun(d *schema.ResourceData, buildRun *build.Build) {
+	d.Set("build_id", converter.ToInt(buildRun.Id, 0))
+	d.Set("build_number", converter.ToString(buildRun.BuildNumber, ""))
+	if buildRun.Status != nil {
+		d.Set("status", string(*buildRun.Status))
+	}
+	if buildRun.Result != nil {
+		d.Set("result", string(*buildRun.Result))
+	}
+	d.Set("url", getBuildRunWebURL(buildRun))
+}
+
+// the url of the build refers to the REST API, the web page of the build is only part of its links
+func getBuildRunWebURL(buildRun *build.Build) string {
+	if links, ok := buildRun.Links.(map[string]interface{}); ok {
+		if web, ok := links["web"].(map[string]interface{}); ok {
+			if href, ok := web["href"].(string); ok {
+				return href
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,311 @@
+// +build all resource_build_run
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuildRunProjectID = uuid.New().String()
+
+func testBuildRun(status build.BuildStatus, result *build.BuildResult) *build.Build {
+	return &build.Build{
+		Id:          converter.Int(1234),
+		BuildNumber: converter.String("20200102.1"),
+		Status:      &status,
+		Result:      result,
+		Links: map[string]interface{}{
+			"web": map[string]interface{}{"href": "https://dev.azure.com/org/project/_build/results?buildId=1234"},
+		},
+	}
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the branch, parameters and variables are sent with the queued build
+func TestBuildRun_Create_QueuesBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRun().Schema, nil)
+	resourceData.Set("project_id", testBuildRunProjectID)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("branch_name", "refs/heads/master")
+	resourceData.Set("parameters", map[string]interface{}{"environment": "bootstrap"})
+	resourceData.Set("variables", map[string]interface{}{"system.debug": "true"})
+
+	expectedArgs := build.QueueBuildArgs{
+		Project: converter.String(testBuildRunProjectID),
+		Build: &build.Build{
+			Definition:   &build.DefinitionReference{Id: converter.Int(10)},
+			SourceBranch: converter.String("refs/heads/master"),
+			Parameters:   converter.String(`{"environment":"bootstrap","system.debug":"true"}`),
+		},
+	}
+	buildClient.
+		EXPECT().
+		QueueBuild(clients.Ctx, expectedArgs).
+		Return(testBuildRun(build.BuildStatusValues.NotStarted, nil), nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetBuild(clients.Ctx, build.GetBuildArgs{Project: converter.String(testBuildRunProjectID), BuildId: converter.Int(1234)}).
+		Return(testBuildRun(build.BuildStatusValues.InProgress, nil), nil).
+		Times(1)
+
+	err := resourceBuildRunCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "1234", resourceData.Id())
+	require.Equal(t, 1234, resourceData.Get("build_id"))
+	require.Equal(t, "20200102.1", resourceData.Get("build_number"))
+	require.Equal(t, "inProgress", resourceData.Get("status"))
+	require.Equal(t, "https://dev.azure.com/org/project/_build/results?buildId=1234", resourceData.Get("url"))
+}
+
+// verifies that a name cannot be used as parameter and as variable, as both are sent in the same dictionary
+func TestBuildRun_Expand_FailsIfParameterIsAlsoVariable(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRun().Schema, nil)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("parameters", map[string]interface{}{"environment": "bootstrap"})
+	resourceData.Set("variables", map[string]interface{}{"environment": "production"})
+
+	_, err := expandBuildRun(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "environment")
+}
+
+// verifies that a name set as parameter and as variable is rejected before the build is queued,
+// instead of queueing the build with either of the values
+func TestBuildRun_Create_DoesNotQueueBuildIfParameterIsAlsoVariable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRun().Schema, nil)
+	resourceData.Set("project_id", testBuildRunProjectID)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("parameters", map[string]interface{}{"environment": "bootstrap", "system.debug": "true"})
+	resourceData.Set("variables", map[string]interface{}{"environment": "production"})
+
+	buildClient.
+		EXPECT().
+		QueueBuild(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceBuildRunCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "environment is set as parameter and as variable")
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the apply fails if the build does not succeed, so that the build is queued again on the next apply
+func TestBuildRun_Create_WaitsForCompletionAndFailsIfBuildFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	defer func(interval time.Duration) { buildRunPollInterval = interval }(buildRunPollInterval)
+	buildRunPollInterval = time.Millisecond
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRun().Schema, nil)
+	resourceData.Set("project_id", testBuildRunProjectID)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("wait_for_completion", true)
+
+	buildClient.
+		EXPECT().
+		QueueBuild(clients.Ctx, gomock.Any()).
+		Return(testBuildRun(build.BuildStatusValues.NotStarted, nil), nil).
+		Times(1)
+	gomock.InOrder(
+		buildClient.
+			EXPECT().
+			GetBuild(clients.Ctx, gomock.Any()).
+			Return(testBuildRun(build.BuildStatusValues.InProgress, nil), nil).
+			Times(1),
+		buildClient.
+			EXPECT().
+			GetBuild(clients.Ctx, gomock.Any()).
+			Return(testBuildRun(build.BuildStatusValues.Completed, &build.BuildResultValues.Failed), nil).
+			Times(1),
+	)
+
+	err := resourceBuildRunCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "did not succeed")
+	require.Equal(t, "1234", resourceData.Id())
+	require.Equal(t, "failed", resourceData.Get("result"))
+}
+
+// verifies that the wait for a build ends once the timeout has expired
+func TestBuildRun_WaitForCompletion_FailsAfterTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	defer func(interval time.Duration) { buildRunPollInterval = interval }(buildRunPollInterval)
+	buildRunPollInterval = time.Millisecond
+
+	buildClient.
+		EXPECT().
+		GetBuild(clients.Ctx, gomock.Any()).
+		Return(testBuildRun(build.BuildStatusValues.InProgress, nil), nil).
+		AnyTimes()
+
+	_, err := waitForBuildCompletion(clients, testBuildRunProjectID, 1234, 20*time.Millisecond)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "did not complete")
+}
+
+// verifies that a build removed by the retention policies does not queue a new build
+func TestBuildRun_Read_KeepsStateIfBuildWasDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuild(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRun().Schema, nil)
+	resourceData.SetId("1234")
+	resourceData.Set("project_id", testBuildRunProjectID)
+
+	err := resourceBuildRunRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "1234", resourceData.Id())
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestBuildRun_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &config.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuild(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetBuild() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceBuildRun().Schema, nil)
+	resourceData.SetId("1234")
+	resourceData.Set("project_id", testBuildRunProjectID)
+
+	err := resourceBuildRunRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetBuild() Failed")
+}
+
+// verifies that a changed trigger queues a new build, while the settings of the wait do not
+func TestBuildRun_Diff_TriggersQueueNewBuild(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                      "1234",
+			"project_id":              testBuildRunProjectID,
+			"definition_id":           "10",
+			"triggers.%":              "1",
+			"triggers.revision":       "1",
+			"wait_for_completion":     "false",
+			"wait_timeout_in_minutes": "60",
+		},
+	}
+
+	diff, err := resourceBuildRun().Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":          testBuildRunProjectID,
+		"definition_id":       10,
+		"triggers":            map[string]interface{}{"revision": "1"},
+		"wait_for_completion": true,
+	}), nil)
+	require.Nil(t, err)
+	require.False(t, diff.RequiresNew())
+
+	diff, err = resourceBuildRun().Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":    testBuildRunProjectID,
+		"definition_id": 10,
+		"triggers":      map[string]interface{}{"revision": "2"},
+	}), nil)
+	require.Nil(t, err)
+	require.True(t, diff.RequiresNew())
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// validates that a build is queued and that a changed trigger queues a new build
+func TestAccBuildRun_QueueAndRequeue(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	buildDefinitionName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBuildRunNode := "azuredevops_build_run.run"
+	var firstBuildID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testhelper.TestAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccBuildRunResource(projectName, buildDefinitionName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfBuildRunNode, "build_id"),
+					resource.TestCheckResourceAttrSet(tfBuildRunNode, "build_number"),
+					resource.TestCheckResourceAttrSet(tfBuildRunNode, "url"),
+					func(s *terraform.State) error {
+						firstBuildID = s.RootModule().Resources[tfBuildRunNode].Primary.ID
+						return nil
+					},
+				),
+			}, {
+				Config: testhelper.TestAccBuildRunResource(projectName, buildDefinitionName, "second"),
+				Check: func(s *terraform.State) error {
+					buildID := s.RootModule().Resources[tfBuildRunNode].Primary.ID
+					if _, err := strconv.Atoi(buildID); err != nil || buildID == firstBuildID {
+						return fmt.Errorf("Expected a new build to be queued, got build %s", buildID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,350 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+const (
+	gitBranchRefPrefix = "refs/heads/"
+	gitTagRefPrefix    = "refs/tags/"
+	gitEmptyObjectID   = "0000000000000000000000000000000000000000"
+)
+
+func resourceGitRepositoryBranch() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitRepositoryBranchCreate,
+		Read:   resourceGitRepositoryBranchRead,
+		Update: resourceGitRepositoryBranchUpdate,
+		Delete: resourceGitRepositoryBranchDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGitRepositoryBranchImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"ref_branch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.NoEmptyStrings,
+				ConflictsWith:    []string{"ref_commit_id"},
+				DiffSuppressFunc: suppressGitRefSourceDiff,
+			},
+			"ref_commit_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.NoEmptyStrings,
+				ConflictsWith:    []string{"ref_branch"},
+				DiffSuppressFunc: suppressGitRefSourceDiff,
+			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"last_commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitRepositoryBranchCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID := d.Get("repository_id").(string)
+	refName := gitBranchRefPrefix + d.Get("name").(string)
+
+	commitID, err := resolveGitRefSource(clients, d, repoID)
+	if err != nil {
+		return fmt.Errorf("Error resolving source of branch %s: %+v", refName, err)
+	}
+
+	err = updateGitRef(clients, repoID, refName, gitEmptyObjectID, commitID)
+	if err != nil {
+		return fmt.Errorf("Error creating branch %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	d.SetId(formatGitRefID(repoID, refName))
+
+	if d.Get("locked").(bool) {
+		err = lockGitBranch(clients, repoID, refName, true)
+		if err != nil {
+			return fmt.Errorf("Error locking branch %s in repository %s: %+v", refName, repoID, err)
+		}
+	}
+
+	return resourceGitRepositoryBranchRead(d, m)
+}
+
+func resourceGitRepositoryBranchRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ref, err := getGitRef(clients, repoID, refName)
+	if err != nil {
+		// the branch is gone as well if the repository was deleted
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error looking up branch %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	if ref == nil {
+		d.SetId("")
+		return nil
+	}
+
+	flattenGitRepositoryBranch(d, repoID, ref)
+	return nil
+}
+
+func resourceGitRepositoryBranchUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("locked") {
+		err = lockGitBranch(clients, repoID, refName, d.Get("locked").(bool))
+		if err != nil {
+			return fmt.Errorf("Error updating lock of branch %s in repository %s: %+v", refName, repoID, err)
+		}
+	}
+
+	return resourceGitRepositoryBranchRead(d, m)
+}
+
+func resourceGitRepositoryBranchDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ref, err := getGitRef(clients, repoID, refName)
+	if err != nil {
+		// the branch is gone as well if the repository was deleted
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error looking up branch %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	if ref == nil {
+		return nil
+	}
+
+	// a locked branch cannot be deleted, so the lock has to be released first
+	if converter.ToBool(ref.IsLocked, false) {
+		err = lockGitBranch(clients, repoID, refName, false)
+		if err != nil {
+			return fmt.Errorf("Error unlocking branch %s in repository %s: %+v", refName, repoID, err)
+		}
+	}
+
+	err = updateGitRef(clients, repoID, refName, *ref.ObjectId, gitEmptyObjectID)
+	if err != nil {
+		return fmt.Errorf("Error deleting branch %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	return nil
+}
+
+func resourceGitRepositoryBranchImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// d.Id() here is the last argument passed to the `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(refName, gitBranchRefPrefix) {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected repositoryId:refs/heads/name", d.Id())
+	}
+
+	d.Set("repository_id", repoID)
+	d.Set("name", strings.TrimPrefix(refName, gitBranchRefPrefix))
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenGitRepositoryBranch(d *schema.ResourceData, repoID string, ref *git.GitRef) {
+	d.Set("repository_id", repoID)
+	d.Set("name", strings.TrimPrefix(converter.ToString(ref.Name, ""), gitBranchRefPrefix))
+	d.Set("locked", converter.ToBool(ref.IsLocked, false))
+	d.Set("last_commit_id", converter.ToString(ref.ObjectId, ""))
+}
+
+// Lock or unlock a branch. Only branches can be locked, tags are immutable anyway
+func lockGitBranch(clients *config.AggregatedClient, repoID string, refName string, locked bool) error {
+	_, err := clients.GitReposClient.UpdateRef(clients.Ctx, git.UpdateRefArgs{
+		RepositoryId: converter.String(repoID),
+		Filter:       converter.String(strings.TrimPrefix(refName, "refs/")),
+		NewRefInfo: &git.GitRefUpdate{
+			IsLocked: converter.Bool(locked),
+		},
+	})
+
+	return err
+}
+
+// Resolve the commit a new ref should point at. The source is either the tip of an existing branch or
+// an explicit commit SHA. If neither is given the tip of the default branch of the repository is used, which
+// is recorded as ref_branch, so that every ref created by Terraform has its source in the state.
+func resolveGitRefSource(clients *config.AggregatedClient, d *schema.ResourceData, repoID string) (string, error) {
+	if refCommitID := d.Get("ref_commit_id").(string); refCommitID != "" {
+		return refCommitID, nil
+	}
+
+	refBranch := d.Get("ref_branch").(string)
+	if refBranch == "" {
+		repo, err := azureGitRepositoryRead(clients, repoID, "", "")
+		if err != nil {
+			return "", err
+		}
+
+		if repo.DefaultBranch == nil || *repo.DefaultBranch == "" {
+			return "", fmt.Errorf("Repository %s has no default branch, either ref_branch or ref_commit_id has to be set", repoID)
+		}
+		refBranch = strings.TrimPrefix(*repo.DefaultBranch, gitBranchRefPrefix)
+		d.Set("ref_branch", refBranch)
+	}
+
+	refName := gitBranchRefPrefix + strings.TrimPrefix(refBranch, gitBranchRefPrefix)
+	ref, err := getGitRef(clients, repoID, refName)
+	if err != nil {
+		return "", err
+	}
+
+	if ref == nil {
+		return "", fmt.Errorf("Branch %s does not exist in repository %s", refName, repoID)
+	}
+
+	return *ref.ObjectId, nil
+}
+
+// The source of a ref is only used on creation and cannot be read back from AzDO. A ref created by Terraform always
+// has its source in the state, so a ref without a source has been imported. Setting the source in the configuration
+// of an imported ref must not recreate it.
+func suppressGitRefSourceDiff(_, _, _ string, d *schema.ResourceData) bool {
+	oldRefBranch, _ := d.GetChange("ref_branch")
+	oldRefCommitID, _ := d.GetChange("ref_commit_id")
+	return d.Id() != "" && oldRefBranch.(string) == "" && oldRefCommitID.(string) == ""
+}
+
+// Create, move or delete a single ref. An empty object ID as old value creates the ref,
+// an empty object ID as new value deletes it.
+func updateGitRef(clients *config.AggregatedClient, repoID string, refName string, oldObjectID string, newObjectID string) error {
+	results, err := clients.GitReposClient.UpdateRefs(clients.Ctx, git.UpdateRefsArgs{
+		RepositoryId: converter.String(repoID),
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String(refName),
+				OldObjectId: converter.String(oldObjectID),
+				NewObjectId: converter.String(newObjectID),
+			},
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, result := range *results {
+		if !converter.ToBool(result.Success, false) {
+			status := ""
+			if result.UpdateStatus != nil {
+				status = string(*result.UpdateStatus)
+			}
+			return fmt.Errorf("Update of ref %s was rejected with status %s: %s", refName, status, converter.ToString(result.CustomMessage, ""))
+		}
+	}
+
+	return nil
+}
+
+// Lookup a single ref by its full name. Returns nil if the ref does not exist.
+func getGitRef(clients *config.AggregatedClient, repoID string, refName string) (*git.GitRef, error) {
+	refs, err := getGitRefs(clients, repoID, strings.TrimPrefix(refName, "refs/"))
+	if err != nil {
+		return nil, err
+	}
+
+	// the filter is a prefix match, so `refs/heads/dev` would also match `refs/heads/develop`
+	for _, ref := range refs {
+		if converter.ToString(ref.Name, "") == refName {
+			return &ref, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Lookup all refs of a repository that start with the filter (e.g. `heads/` or `tags/v1`). This involves
+// querying a paginated API, so multiple API calls may be needed.
+func getGitRefs(clients *config.AggregatedClient, repoID string, filter string) ([]git.GitRef, error) {
+	var refs []git.GitRef
+	var currentToken string
+
+	for hasMore := true; hasMore; {
+		args := git.GetRefsArgs{
+			RepositoryId: converter.String(repoID),
+			Filter:       converter.String(filter),
+			PeelTags:     converter.Bool(true),
+		}
+		if currentToken != "" {
+			args.ContinuationToken = converter.String(currentToken)
+		}
+
+		response, err := clients.GitReposClient.GetRefs(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		refs = append(refs, response.Value...)
+		currentToken = response.ContinuationToken
+		hasMore = currentToken != ""
+	}
+
+	return refs, nil
+}
+
+func formatGitRefID(repoID string, refName string) string {
+	return fmt.Sprintf("%s:%s", repoID, refName)
+}
+
+// Parse the ID of a ref resource. The format is `repositoryId:refs/heads/name` or `repositoryId:refs/tags/name`
+func parseGitRefID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || !strings.HasPrefix(parts[1], "refs/") {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected repositoryId:refs/(heads|tags)/name", id)
+	}
+
+	return parts[0], parts[1], nil
+}
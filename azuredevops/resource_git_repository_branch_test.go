@@ -0,0 +1,410 @@
+// +build all core resource_git_repository_branch
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testBranchRepoID = uuid.New().String()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the ID of a ref resource can be parsed and rejects malformed IDs
+func TestGitRepositoryBranch_ParseGitRefID(t *testing.T) {
+	repoID, refName, err := parseGitRefID(testBranchRepoID + ":refs/heads/release/1.0")
+	require.Nil(t, err)
+	require.Equal(t, testBranchRepoID, repoID)
+	require.Equal(t, "refs/heads/release/1.0", refName)
+
+	for _, id := range []string{"", testBranchRepoID, testBranchRepoID + ":master", ":refs/heads/master"} {
+		_, _, err := parseGitRefID(id)
+		require.NotNil(t, err, "ID %s unexpectedly passed parsing", id)
+	}
+}
+
+// verifies that a branch can only be imported using a branch ref
+func TestGitRepositoryBranch_Import_RequiresBranchRef(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.SetId(testBranchRepoID + ":refs/tags/v1.0")
+
+	_, err := resourceGitRepositoryBranchImport(resourceData, &config.AggregatedClient{})
+	require.NotNil(t, err)
+
+	resourceData.SetId(testBranchRepoID + ":refs/heads/release/1.0")
+	_, err = resourceGitRepositoryBranchImport(resourceData, &config.AggregatedClient{})
+	require.Nil(t, err)
+	require.Equal(t, testBranchRepoID, resourceData.Get("repository_id"))
+	require.Equal(t, "release/1.0", resourceData.Get("name"))
+}
+
+// verifies that setting the source of an imported branch does not plan to recreate it,
+// while changing the source of a branch created by terraform still does
+func TestGitRepositoryBranch_Diff_ImportedBranchIsNotRecreated(t *testing.T) {
+	r := resourceGitRepositoryBranch()
+	clients := &config.AggregatedClient{Ctx: context.Background()}
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"repository_id": testBranchRepoID,
+		"name":          "release/1.0",
+		"ref_branch":    "master",
+	})
+
+	importedState := &terraform.InstanceState{
+		ID: testBranchRepoID + ":refs/heads/release/1.0",
+		Attributes: map[string]string{
+			"id":             testBranchRepoID + ":refs/heads/release/1.0",
+			"repository_id":  testBranchRepoID,
+			"name":           "release/1.0",
+			"locked":         "false",
+			"last_commit_id": "abc123",
+		},
+	}
+	diff, err := r.Diff(importedState, cfg, clients)
+	require.Nil(t, err)
+	require.True(t, diff == nil || !diff.RequiresNew())
+
+	createdState := importedState.DeepCopy()
+	createdState.Attributes["ref_branch"] = "develop"
+	diff, err = r.Diff(createdState, cfg, clients)
+	require.Nil(t, err)
+	require.NotNil(t, diff)
+	require.True(t, diff.RequiresNew())
+
+	// a branch created from the default branch records it as its source
+	createdFromDefaultBranchState := importedState.DeepCopy()
+	createdFromDefaultBranchState.Attributes["ref_branch"] = "master"
+	diff, err = r.Diff(createdFromDefaultBranchState, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"repository_id": testBranchRepoID,
+		"name":          "release/1.0",
+		"ref_commit_id": "def456",
+	}), clients)
+	require.Nil(t, err)
+	require.NotNil(t, diff)
+	require.True(t, diff.RequiresNew())
+}
+
+// verifies that a branch created without a source records the default branch as its source
+func TestGitRepositoryBranch_Create_RecordsDefaultBranchAsSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.Set("repository_id", testBranchRepoID)
+	resourceData.Set("name", "release")
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, git.GetRepositoryArgs{RepositoryId: converter.String(testBranchRepoID), Project: converter.String("")}).
+		Return(&git.GitRepository{DefaultBranch: converter.String("refs/heads/main")}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/main"), ObjectId: converter.String("abc123")},
+			},
+		}, nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdateRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryBranchCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRefs() Failed")
+	require.Equal(t, "main", resourceData.Get("ref_branch"))
+}
+
+// verifies that the branch is created from the tip of the referenced branch
+func TestGitRepositoryBranch_Create_UsesCommitOfRefBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.Set("repository_id", testBranchRepoID)
+	resourceData.Set("name", "release")
+	resourceData.Set("ref_branch", "master")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/master"), ObjectId: converter.String("abc123")},
+			},
+		}, nil).
+		Times(1)
+
+	expectedArgs := git.UpdateRefsArgs{
+		RepositoryId: converter.String(testBranchRepoID),
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String("refs/heads/release"),
+				OldObjectId: converter.String(gitEmptyObjectID),
+				NewObjectId: converter.String("abc123"),
+			},
+		},
+	}
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryBranchCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRefs() Failed")
+}
+
+// verifies that a rejected ref update is reported as an error
+func TestGitRepositoryBranch_Create_DoesNotSwallowRejectedUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.Set("repository_id", testBranchRepoID)
+	resourceData.Set("name", "release")
+	resourceData.Set("ref_commit_id", "abc123")
+
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, gomock.Any()).
+		Return(&[]git.GitRefUpdateResult{
+			{
+				Success:      converter.Bool(false),
+				UpdateStatus: &git.GitRefUpdateStatusValues.CreateBranchPermissionRequired,
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryBranchCreate(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "createBranchPermissionRequired")
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a branch that no longer exists is removed from the state
+func TestGitRepositoryBranch_Read_ClearsIDIfBranchDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.SetId(testBranchRepoID + ":refs/heads/dev")
+
+	// the filter is a prefix match, so similarly named branches have to be ignored
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/develop"), ObjectId: converter.String("abc123")},
+			},
+		}, nil).
+		Times(1)
+
+	err := resourceGitRepositoryBranchRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a branch is removed from the state if its repository no longer exists
+func TestGitRepositoryBranch_Read_ClearsIDIfRepositoryDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.SetId(testBranchRepoID + ":refs/heads/dev")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := resourceGitRepositoryBranchRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestGitRepositoryBranch_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.SetId(testBranchRepoID + ":refs/heads/dev")
+
+	expectedArgs := git.GetRefsArgs{
+		RepositoryId: converter.String(testBranchRepoID),
+		Filter:       converter.String("heads/dev"),
+		PeelTags:     converter.Bool(true),
+	}
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryBranchRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetRefs() Failed")
+}
+
+// verifies that a locked branch is unlocked before it is deleted
+func TestGitRepositoryBranch_Delete_UnlocksLockedBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryBranch().Schema, nil)
+	resourceData.SetId(testBranchRepoID + ":refs/heads/dev")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{
+				{Name: converter.String("refs/heads/dev"), ObjectId: converter.String("abc123"), IsLocked: converter.Bool(true)},
+			},
+		}, nil).
+		Times(1)
+
+	unlockArgs := git.UpdateRefArgs{
+		RepositoryId: converter.String(testBranchRepoID),
+		Filter:       converter.String("heads/dev"),
+		NewRefInfo:   &git.GitRefUpdate{IsLocked: converter.Bool(false)},
+	}
+	reposClient.
+		EXPECT().
+		UpdateRef(clients.Ctx, unlockArgs).
+		Return(&git.GitRef{}, nil).
+		Times(1)
+
+	deleteArgs := git.UpdateRefsArgs{
+		RepositoryId: converter.String(testBranchRepoID),
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String("refs/heads/dev"),
+				OldObjectId: converter.String("abc123"),
+				NewObjectId: converter.String(gitEmptyObjectID),
+			},
+		},
+	}
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, deleteArgs).
+		Return(nil, errors.New("UpdateRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryBranchDelete(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRefs() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a branch can be created from another branch, locked afterwards and imported
+func TestAccGitRepositoryBranch_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	branchName := "release/" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfBranchNode := "azuredevops_git_repository_branch.branch"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGitRepositoryBranchCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccGitRepositoryBranchResource(projectName, gitRepoName, branchName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfBranchNode, "repository_id"),
+					resource.TestCheckResourceAttr(tfBranchNode, "name", branchName),
+					resource.TestCheckResourceAttr(tfBranchNode, "locked", "false"),
+					resource.TestCheckResourceAttrSet(tfBranchNode, "last_commit_id"),
+				),
+			},
+			{
+				Config: testhelper.TestAccGitRepositoryBranchResource(projectName, gitRepoName, branchName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfBranchNode, "name", branchName),
+					resource.TestCheckResourceAttr(tfBranchNode, "locked", "true"),
+				),
+			},
+			{
+				ResourceName:            tfBranchNode,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref_branch"},
+			},
+		},
+	})
+}
+
+func testAccGitRepositoryBranchCheckDestroy(s *terraform.State) error {
+	clients := testAccProvider.Meta().(*config.AggregatedClient)
+
+	// verify that every branch referenced in the state does not exist in AzDO
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "azuredevops_git_repository_branch" {
+			continue
+		}
+
+		repoID, refName, err := parseGitRefID(resource.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		// the repository is destroyed as well, so a failed lookup is fine here
+		if ref, err := getGitRef(clients, repoID, refName); err == nil && ref != nil {
+			return fmt.Errorf("branch %s should not exist", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	InitProvider()
+}
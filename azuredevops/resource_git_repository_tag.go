@@ -0,0 +1,236 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+func resourceGitRepositoryTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitRepositoryTagCreate,
+		Read:   resourceGitRepositoryTagRead,
+		Delete: resourceGitRepositoryTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGitRepositoryTagImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.UUID,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+			"ref_branch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.NoEmptyStrings,
+				ConflictsWith:    []string{"ref_commit_id"},
+				DiffSuppressFunc: suppressGitRefSourceDiff,
+			},
+			"ref_commit_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.NoEmptyStrings,
+				ConflictsWith:    []string{"ref_branch"},
+				DiffSuppressFunc: suppressGitRefSourceDiff,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "",
+			},
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitRepositoryTagCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID := d.Get("repository_id").(string)
+	tagName := d.Get("name").(string)
+	refName := gitTagRefPrefix + tagName
+
+	commitID, err := resolveGitRefSource(clients, d, repoID)
+	if err != nil {
+		return fmt.Errorf("Error resolving source of tag %s: %+v", refName, err)
+	}
+
+	// a tag with a message is an annotated tag, which is a git object on its own. Lightweight
+	// tags are plain refs pointing at the commit.
+	message := d.Get("message").(string)
+	if message != "" {
+		err = createGitAnnotatedTag(clients, repoID, tagName, message, commitID)
+	} else {
+		err = updateGitRef(clients, repoID, refName, gitEmptyObjectID, commitID)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error creating tag %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	d.SetId(formatGitRefID(repoID, refName))
+	return resourceGitRepositoryTagRead(d, m)
+}
+
+func createGitAnnotatedTag(clients *config.AggregatedClient, repoID string, tagName string, message string, commitID string) error {
+	projectID, err := getGitRepositoryProjectID(clients, repoID)
+	if err != nil {
+		return err
+	}
+
+	_, err = clients.GitReposClient.CreateAnnotatedTag(clients.Ctx, git.CreateAnnotatedTagArgs{
+		Project:      converter.String(projectID),
+		RepositoryId: converter.String(repoID),
+		TagObject: &git.GitAnnotatedTag{
+			Name:    converter.String(tagName),
+			Message: converter.String(message),
+			TaggedObject: &git.GitObject{
+				ObjectId: converter.String(commitID),
+			},
+		},
+	})
+
+	return err
+}
+
+func resourceGitRepositoryTagRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ref, err := getGitRef(clients, repoID, refName)
+	if err != nil {
+		// the tag is gone as well if the repository was deleted
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error looking up tag %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	if ref == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// only annotated tags are peeled, lightweight tags point directly at the commit
+	var annotatedTag *git.GitAnnotatedTag
+	if converter.ToString(ref.PeeledObjectId, "") != "" {
+		annotatedTag, err = getGitAnnotatedTag(clients, repoID, *ref.ObjectId)
+		if err != nil {
+			return fmt.Errorf("Error looking up annotated tag %s in repository %s: %+v", refName, repoID, err)
+		}
+	}
+
+	flattenGitRepositoryTag(d, repoID, ref, annotatedTag)
+	return nil
+}
+
+func getGitAnnotatedTag(clients *config.AggregatedClient, repoID string, objectID string) (*git.GitAnnotatedTag, error) {
+	projectID, err := getGitRepositoryProjectID(clients, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.GitReposClient.GetAnnotatedTag(clients.Ctx, git.GetAnnotatedTagArgs{
+		Project:      converter.String(projectID),
+		RepositoryId: converter.String(repoID),
+		ObjectId:     converter.String(objectID),
+	})
+}
+
+// The annotated tag APIs require the project, which is not part of the ID of ref resources
+func getGitRepositoryProjectID(clients *config.AggregatedClient, repoID string) (string, error) {
+	repo, err := azureGitRepositoryRead(clients, repoID, "", "")
+	if err != nil {
+		return "", err
+	}
+
+	return repo.Project.Id.String(), nil
+}
+
+func resourceGitRepositoryTagDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*config.AggregatedClient)
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ref, err := getGitRef(clients, repoID, refName)
+	if err != nil {
+		// the tag is gone as well if the repository was deleted
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error looking up tag %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	if ref == nil {
+		return nil
+	}
+
+	err = updateGitRef(clients, repoID, refName, *ref.ObjectId, gitEmptyObjectID)
+	if err != nil {
+		return fmt.Errorf("Error deleting tag %s in repository %s: %+v", refName, repoID, err)
+	}
+
+	return nil
+}
+
+func resourceGitRepositoryTagImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// d.Id() here is the last argument passed to the `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
+	repoID, refName, err := parseGitRefID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(refName, gitTagRefPrefix) {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected repositoryId:refs/tags/name", d.Id())
+	}
+
+	d.Set("repository_id", repoID)
+	d.Set("name", strings.TrimPrefix(refName, gitTagRefPrefix))
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenGitRepositoryTag(d *schema.ResourceData, repoID string, ref *git.GitRef, annotatedTag *git.GitAnnotatedTag) {
+	d.Set("repository_id", repoID)
+	d.Set("name", strings.TrimPrefix(converter.ToString(ref.Name, ""), gitTagRefPrefix))
+	d.Set("object_id", converter.ToString(ref.ObjectId, ""))
+
+	if annotatedTag != nil {
+		d.Set("message", converter.ToString(annotatedTag.Message, ""))
+		d.Set("commit_id", converter.ToString(ref.PeeledObjectId, ""))
+	} else {
+		d.Set("message", "")
+		d.Set("commit_id", converter.ToString(ref.ObjectId, ""))
+	}
+}
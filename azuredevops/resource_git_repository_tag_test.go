@@ -0,0 +1,276 @@
+// +build all core resource_git_repository_tag
+
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testTagRepoID = uuid.New()
+var testTagProjectID = uuid.New()
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that a tag can only be imported using a tag ref
+func TestGitRepositoryTag_Import_RequiresTagRef(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryTag().Schema, nil)
+	resourceData.SetId(testTagRepoID.String() + ":refs/heads/master")
+
+	_, err := resourceGitRepositoryTagImport(resourceData, &config.AggregatedClient{})
+	require.NotNil(t, err)
+
+	resourceData.SetId(testTagRepoID.String() + ":refs/tags/v1.0")
+	_, err = resourceGitRepositoryTagImport(resourceData, &config.AggregatedClient{})
+	require.Nil(t, err)
+	require.Equal(t, testTagRepoID.String(), resourceData.Get("repository_id"))
+	require.Equal(t, "v1.0", resourceData.Get("name"))
+}
+
+// verifies that a tag without a message is created as lightweight tag
+func TestGitRepositoryTag_Create_LightweightTagUsesRefUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryTag().Schema, nil)
+	resourceData.Set("repository_id", testTagRepoID.String())
+	resourceData.Set("name", "v1.0")
+	resourceData.Set("ref_commit_id", "abc123")
+
+	expectedArgs := git.UpdateRefsArgs{
+		RepositoryId: converter.String(testTagRepoID.String()),
+		RefUpdates: &[]git.GitRefUpdate{
+			{
+				Name:        converter.String("refs/tags/v1.0"),
+				OldObjectId: converter.String(gitEmptyObjectID),
+				NewObjectId: converter.String("abc123"),
+			},
+		},
+	}
+	reposClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryTagCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateRefs() Failed")
+}
+
+// verifies that a tag with a message is created as annotated tag
+func TestGitRepositoryTag_Create_AnnotatedTagUsesAnnotatedTagAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryTag().Schema, nil)
+	resourceData.Set("repository_id", testTagRepoID.String())
+	resourceData.Set("name", "v1.0")
+	resourceData.Set("ref_commit_id", "abc123")
+	resourceData.Set("message", "Release 1.0")
+
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&git.GitRepository{Id: &testTagRepoID, Project: &core.TeamProjectReference{Id: &testTagProjectID}}, nil).
+		Times(1)
+
+	expectedArgs := git.CreateAnnotatedTagArgs{
+		Project:      converter.String(testTagProjectID.String()),
+		RepositoryId: converter.String(testTagRepoID.String()),
+		TagObject: &git.GitAnnotatedTag{
+			Name:         converter.String("v1.0"),
+			Message:      converter.String("Release 1.0"),
+			TaggedObject: &git.GitObject{ObjectId: converter.String("abc123")},
+		},
+	}
+	reposClient.
+		EXPECT().
+		CreateAnnotatedTag(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("CreateAnnotatedTag() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryTagCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "CreateAnnotatedTag() Failed")
+}
+
+// verifies that setting the source of an imported tag does not plan to recreate it
+func TestGitRepositoryTag_Diff_ImportedTagIsNotRecreated(t *testing.T) {
+	r := resourceGitRepositoryTag()
+	importedState := &terraform.InstanceState{
+		ID: testTagRepoID.String() + ":refs/tags/v1.0",
+		Attributes: map[string]string{
+			"id":            testTagRepoID.String() + ":refs/tags/v1.0",
+			"repository_id": testTagRepoID.String(),
+			"name":          "v1.0",
+			"message":       "",
+			"object_id":     "abc123",
+			"commit_id":     "abc123",
+		},
+	}
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"repository_id": testTagRepoID.String(),
+		"name":          "v1.0",
+		"ref_commit_id": "abc123",
+	})
+
+	diff, err := r.Diff(importedState, cfg, &config.AggregatedClient{Ctx: context.Background()})
+	require.Nil(t, err)
+	require.True(t, diff == nil || !diff.RequiresNew())
+}
+
+// verifies that a tag is removed from the state if its repository no longer exists
+func TestGitRepositoryTag_Read_ClearsIDIfRepositoryDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryTag().Schema, nil)
+	resourceData.SetId(testTagRepoID.String() + ":refs/tags/v1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := resourceGitRepositoryTagRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the commit of an annotated tag is taken from the peeled object
+func TestGitRepositoryTag_Flatten_AnnotatedTag(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryTag().Schema, nil)
+	ref := git.GitRef{
+		Name:           converter.String("refs/tags/v1.0"),
+		ObjectId:       converter.String("tag123"),
+		PeeledObjectId: converter.String("commit123"),
+	}
+	annotatedTag := git.GitAnnotatedTag{Message: converter.String("Release 1.0")}
+
+	flattenGitRepositoryTag(resourceData, testTagRepoID.String(), &ref, &annotatedTag)
+
+	require.Equal(t, "v1.0", resourceData.Get("name"))
+	require.Equal(t, "tag123", resourceData.Get("object_id"))
+	require.Equal(t, "commit123", resourceData.Get("commit_id"))
+	require.Equal(t, "Release 1.0", resourceData.Get("message"))
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestGitRepositoryTag_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &config.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceGitRepositoryTag().Schema, nil)
+	resourceData.SetId(testTagRepoID.String() + ":refs/tags/v1.0")
+
+	reposClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetRefs() Failed")).
+		Times(1)
+
+	err := resourceGitRepositoryTagDelete(resourceData, clients)
+	require.Contains(t, err.Error(), "GetRefs() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that lightweight and annotated tags can be created and imported
+func TestAccGitRepositoryTag_Create(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tagName := "v" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfTagNode := "azuredevops_git_repository_tag.tag"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGitRepositoryTagCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccGitRepositoryTagResource(projectName, gitRepoName, tagName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfTagNode, "name", tagName),
+					resource.TestCheckResourceAttrSet(tfTagNode, "commit_id"),
+					resource.TestCheckResourceAttrPair(tfTagNode, "commit_id", tfTagNode, "object_id"),
+				),
+			},
+			{
+				Config: testhelper.TestAccGitRepositoryTagResource(projectName, gitRepoName, tagName, "Annotated tag"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfTagNode, "name", tagName),
+					resource.TestCheckResourceAttr(tfTagNode, "message", "Annotated tag"),
+					resource.TestCheckResourceAttrSet(tfTagNode, "commit_id"),
+					resource.TestCheckResourceAttrSet(tfTagNode, "object_id"),
+				),
+			},
+			{
+				ResourceName:            tfTagNode,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref_branch"},
+			},
+		},
+	})
+}
+
+func testAccGitRepositoryTagCheckDestroy(s *terraform.State) error {
+	clients := testAccProvider.Meta().(*config.AggregatedClient)
+
+	// verify that every tag referenced in the state does not exist in AzDO
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "azuredevops_git_repository_tag" {
+			continue
+		}
+
+		repoID, refName, err := parseGitRefID(resource.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		// the repository is destroyed as well, so a failed lookup is fine here
+		if ref, err := getGitRef(clients, repoID, refName); err == nil && ref != nil {
+			return fmt.Errorf("tag %s should not exist", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,70 @@
+package azuredevops
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/validate"
+)
+
+// resourcePolicyConfiguration manages a policy of any type. Unlike the dedicated policy resources,
+// the settings are passed through to AzDO as a raw JSON document, scope included.
+func resourcePolicyConfiguration() *schema.Resource {
+	r := crud.GenBasePolicyResource(flattenPolicyConfiguration, expandPolicyConfiguration)
+
+	r.Schema["type_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validate.UUID,
+	}
+	r.Schema[crud.SchemaSettings] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.ValidateJsonString,
+		DiffSuppressFunc: suppress.JSONDifference,
+	}
+
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandPolicyConfiguration(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	typeID, err := uuid.Parse(d.Get("type_id").(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing policy type ID: %+v", err)
+	}
+
+	policyConfig, projectID := crud.DoBasePolicyExpansion(d, typeID)
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get(crud.SchemaSettings).(string)), &settings); err != nil {
+		return nil, nil, fmt.Errorf("Error parsing policy settings: %+v", err)
+	}
+	policyConfig.Settings = settings
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenPolicyConfiguration(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	crud.DoBasePolicyFlattening(d, policyConfig, projectID)
+
+	if policyConfig.Type != nil && policyConfig.Type.Id != nil {
+		d.Set("type_id", policyConfig.Type.Id.String())
+	}
+
+	settings, err := json.Marshal(policyConfig.Settings)
+	if err != nil {
+		return fmt.Errorf("Error reading the settings of policy %s: %+v", d.Id(), err)
+	}
+	return d.Set(crud.SchemaSettings, string(settings))
+}
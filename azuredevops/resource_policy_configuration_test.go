@@ -0,0 +1,194 @@
+// +build all core resource_policy_configuration
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicyConfigurationProjectID = uuid.New().String()
+var testPolicyConfigurationTypeID = uuid.New()
+
+var testPolicyConfiguration = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(false),
+	Type: &policy.PolicyTypeRef{
+		Id: &testPolicyConfigurationTypeID,
+	},
+	Settings: map[string]interface{}{
+		"someSetting": "value",
+		"nested": map[string]interface{}{
+			"count":   float64(3),
+			"enabled": true,
+		},
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": nil,
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestPolicyConfiguration_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourcePolicyConfiguration().Schema, nil)
+	err := flattenPolicyConfiguration(resourceData, nil, &testPolicyConfiguration, &testPolicyConfigurationProjectID)
+	require.Nil(t, err)
+	require.Equal(t, testPolicyConfigurationTypeID.String(), resourceData.Get("type_id"))
+
+	policyAfterRoundTrip, projectID, err := expandPolicyConfiguration(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testPolicyConfigurationProjectID, *projectID)
+	require.Equal(t, testPolicyConfiguration.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testPolicyConfiguration.IsEnabled, policyAfterRoundTrip.IsEnabled)
+	require.Equal(t, testPolicyConfiguration.IsBlocking, policyAfterRoundTrip.IsBlocking)
+	require.Equal(t, testPolicyConfiguration.Type, policyAfterRoundTrip.Type)
+	require.Equal(t, testPolicyConfiguration.Settings, policyAfterRoundTrip.Settings)
+}
+
+// verifies that the settings document is sent to AzDO as is
+func TestPolicyConfiguration_Expand_PassesSettingsThrough(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourcePolicyConfiguration().Schema, map[string]interface{}{
+		"project_id": testPolicyConfigurationProjectID,
+		"type_id":    testPolicyConfigurationTypeID.String(),
+		"settings":   `{"scope": [{"repositoryId": null}], "someSetting": "value", "nested": {"enabled": true, "count": 3}}`,
+	})
+
+	policyConfig, _, err := expandPolicyConfiguration(resourceData, nil)
+	require.Nil(t, err)
+
+	expectedSettings, _ := json.Marshal(testPolicyConfiguration.Settings)
+	actualSettings, _ := json.Marshal(policyConfig.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestPolicyConfiguration_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourcePolicyConfiguration()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenPolicyConfiguration(resourceData, nil, &testPolicyConfiguration, &testPolicyConfigurationProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+// verifies that the resource is removed from the state if the policy no longer exists
+func TestPolicyConfiguration_Read_ClearsIDIfPolicyDoesNotExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourcePolicyConfiguration()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenPolicyConfiguration(resourceData, nil, &testPolicyConfiguration, &testPolicyConfigurationProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		GetPolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestPolicyConfiguration_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourcePolicyConfiguration()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenPolicyConfiguration(resourceData, nil, &testPolicyConfiguration, &testPolicyConfigurationProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		UpdatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a generic policy can be created, updated and imported without a perpetual diff of its settings
+func TestAccPolicyConfiguration_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_policy_configuration.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_policy_configuration"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccPolicyConfigurationResource(projectName, gitRepoName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "type_id", minReviewersPolicyTypeID.String()),
+				),
+			},
+			{
+				Config: testhelper.TestAccPolicyConfigurationResource(projectName, gitRepoName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,50 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Git repository settings" policy type, which holds the case enforcement setting
+var caseEnforcementPolicyTypeID = uuid.MustParse("7ed39669-655c-494e-b4a0-a08b4da0fcce")
+
+// the settings of a case enforcement policy as they are stored in the policy configuration
+type caseEnforcementPolicySettings struct {
+	EnforceConsistentCase bool `json:"enforceConsistentCase"`
+}
+
+func resourceRepositoryPolicyCaseEnforcement() *schema.Resource {
+	r := crud.GenBaseRepositoryPolicyResource(flattenRepositoryPolicyCaseEnforcement, expandRepositoryPolicyCaseEnforcement)
+	r.Schema["enforce_consistent_case"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+	}
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandRepositoryPolicyCaseEnforcement(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseRepositoryPolicyExpansion(d, caseEnforcementPolicyTypeID)
+	settings["enforceConsistentCase"] = d.Get("enforce_consistent_case").(bool)
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenRepositoryPolicyCaseEnforcement(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	if err := crud.DoBaseRepositoryPolicyFlattening(d, policyConfig, projectID); err != nil {
+		return err
+	}
+
+	var policySettings caseEnforcementPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	d.Set("enforce_consistent_case", policySettings.EnforceConsistentCase)
+	return nil
+}
@@ -0,0 +1,127 @@
+// +build all core resource_repository_policy_case_enforcement
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testCaseEnforcementPolicyProjectID = uuid.New().String()
+
+var testCaseEnforcementPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &caseEnforcementPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"enforceConsistentCase": true,
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestRepositoryPolicyCaseEnforcement_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceRepositoryPolicyCaseEnforcement().Schema, nil)
+	err := flattenRepositoryPolicyCaseEnforcement(resourceData, nil, &testCaseEnforcementPolicy, &testCaseEnforcementPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandRepositoryPolicyCaseEnforcement(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testCaseEnforcementPolicyProjectID, *projectID)
+	require.Equal(t, testCaseEnforcementPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testCaseEnforcementPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testCaseEnforcementPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestRepositoryPolicyCaseEnforcement_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceRepositoryPolicyCaseEnforcement()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRepositoryPolicyCaseEnforcement(resourceData, nil, &testCaseEnforcementPolicy, &testCaseEnforcementPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a case enforcement policy can be created, updated and imported
+func TestAccRepositoryPolicyCaseEnforcement_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_repository_policy_case_enforcement.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_repository_policy_case_enforcement"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_case_enforcement", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "repository_ids.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "enforce_consistent_case", "true"),
+				),
+			},
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_case_enforcement", "enforce_consistent_case = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "enforce_consistent_case", "false"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,64 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "File size restriction" policy type
+var maxFileSizePolicyTypeID = uuid.MustParse("2e26e725-8201-4edd-8bf5-978563c34a80")
+
+const bytesPerMegabyte = 1024 * 1024
+
+// the settings of a file size policy as they are stored in the policy configuration
+type maxFileSizePolicySettings struct {
+	MaximumGitBlobSizeInBytes int64 `json:"maximumGitBlobSizeInBytes"`
+	UseUncompressedSize       bool  `json:"useUncompressedSize"`
+}
+
+func resourceRepositoryPolicyMaxFileSize() *schema.Resource {
+	r := crud.GenBaseRepositoryPolicyResource(flattenRepositoryPolicyMaxFileSize, expandRepositoryPolicyMaxFileSize)
+	r.Schema["max_file_size"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+		// the file size limits which are offered by AzDO, in megabytes
+		ValidateFunc: validation.IntInSlice([]int{1, 2, 5, 10, 50, 100, 200}),
+	}
+	r.Schema["use_uncompressed_size"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandRepositoryPolicyMaxFileSize(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseRepositoryPolicyExpansion(d, maxFileSizePolicyTypeID)
+
+	settings["maximumGitBlobSizeInBytes"] = int64(d.Get("max_file_size").(int)) * bytesPerMegabyte
+	settings["useUncompressedSize"] = d.Get("use_uncompressed_size").(bool)
+
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenRepositoryPolicyMaxFileSize(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	if err := crud.DoBaseRepositoryPolicyFlattening(d, policyConfig, projectID); err != nil {
+		return err
+	}
+
+	var policySettings maxFileSizePolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	d.Set("max_file_size", int(policySettings.MaximumGitBlobSizeInBytes/bytesPerMegabyte))
+	d.Set("use_uncompressed_size", policySettings.UseUncompressedSize)
+	return nil
+}
@@ -0,0 +1,154 @@
+// +build all core resource_repository_policy_max_file_size
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testMaxFileSizePolicyProjectID = uuid.New().String()
+
+var testMaxFileSizePolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &maxFileSizePolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"maximumGitBlobSizeInBytes": 10 * bytesPerMegabyte,
+		"useUncompressedSize":       true,
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestRepositoryPolicyMaxFileSize_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceRepositoryPolicyMaxFileSize().Schema, nil)
+	err := flattenRepositoryPolicyMaxFileSize(resourceData, nil, &testMaxFileSizePolicy, &testMaxFileSizePolicyProjectID)
+	require.Nil(t, err)
+	require.Equal(t, 10, resourceData.Get("max_file_size"))
+
+	policyAfterRoundTrip, projectID, err := expandRepositoryPolicyMaxFileSize(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testMaxFileSizePolicyProjectID, *projectID)
+	require.Equal(t, testMaxFileSizePolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testMaxFileSizePolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testMaxFileSizePolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that the policy is created with the ID of the "File size restriction" policy type
+func TestRepositoryPolicyMaxFileSize_Create_UsesFileSizeRestrictionPolicyType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceRepositoryPolicyMaxFileSize()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRepositoryPolicyMaxFileSize(resourceData, nil, &testMaxFileSizePolicy, &testMaxFileSizePolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args policy.CreatePolicyConfigurationArgs) (*policy.PolicyConfiguration, error) {
+			require.Equal(t, "2e26e725-8201-4edd-8bf5-978563c34a80", args.Configuration.Type.Id.String())
+			return nil, errors.New("CreatePolicyConfiguration() Failed")
+		}).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestRepositoryPolicyMaxFileSize_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceRepositoryPolicyMaxFileSize()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRepositoryPolicyMaxFileSize(resourceData, nil, &testMaxFileSizePolicy, &testMaxFileSizePolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a file size policy can be created, updated and imported
+func TestAccRepositoryPolicyMaxFileSize_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_repository_policy_max_file_size.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_repository_policy_max_file_size"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_max_file_size", "max_file_size = 10"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "repository_ids.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "max_file_size", "10"),
+				),
+			},
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_max_file_size", "max_file_size = 100"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "max_file_size", "100"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
@@ -0,0 +1,51 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Path Length restriction" policy type
+var maxPathLengthPolicyTypeID = uuid.MustParse("001a79cf-fda1-4c4e-9e7c-bac40ee5ead8")
+
+// the settings of a path length policy as they are stored in the policy configuration
+type maxPathLengthPolicySettings struct {
+	MaxPathLength int `json:"maxPathLength"`
+}
+
+func resourceRepositoryPolicyMaxPathLength() *schema.Resource {
+	r := crud.GenBaseRepositoryPolicyResource(flattenRepositoryPolicyMaxPathLength, expandRepositoryPolicyMaxPathLength)
+	r.Schema["max_path_length"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	return r
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandRepositoryPolicyMaxPathLength(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, settings := crud.DoBaseRepositoryPolicyExpansion(d, maxPathLengthPolicyTypeID)
+	settings["maxPathLength"] = d.Get("max_path_length").(int)
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenRepositoryPolicyMaxPathLength(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	if err := crud.DoBaseRepositoryPolicyFlattening(d, policyConfig, projectID); err != nil {
+		return err
+	}
+
+	var policySettings maxPathLengthPolicySettings
+	if err := crud.DecodeSettings(policyConfig, &policySettings); err != nil {
+		return err
+	}
+
+	d.Set("max_path_length", policySettings.MaxPathLength)
+	return nil
+}
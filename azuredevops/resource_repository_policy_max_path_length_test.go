@@ -0,0 +1,127 @@
+// +build all core resource_repository_policy_max_path_length
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testMaxPathLengthPolicyProjectID = uuid.New().String()
+
+var testMaxPathLengthPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &maxPathLengthPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"maxPathLength": 248,
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestRepositoryPolicyMaxPathLength_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceRepositoryPolicyMaxPathLength().Schema, nil)
+	err := flattenRepositoryPolicyMaxPathLength(resourceData, nil, &testMaxPathLengthPolicy, &testMaxPathLengthPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandRepositoryPolicyMaxPathLength(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testMaxPathLengthPolicyProjectID, *projectID)
+	require.Equal(t, testMaxPathLengthPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testMaxPathLengthPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testMaxPathLengthPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestRepositoryPolicyMaxPathLength_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceRepositoryPolicyMaxPathLength()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRepositoryPolicyMaxPathLength(resourceData, nil, &testMaxPathLengthPolicy, &testMaxPathLengthPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a path length policy can be created, updated and imported
+func TestAccRepositoryPolicyMaxPathLength_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_repository_policy_max_path_length.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_repository_policy_max_path_length"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_max_path_length", "max_path_length = 248"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "repository_ids.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "max_path_length", "248"),
+				),
+			},
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_max_path_length", "max_path_length = 1000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "max_path_length", "1000"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
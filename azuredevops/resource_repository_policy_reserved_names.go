@@ -0,0 +1,29 @@
+package azuredevops
+
+import (
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+
+	crud "github.com/microsoft/terraform-provider-azuredevops/azuredevops/crud/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+)
+
+// the ID of the "Reserved names restriction" policy type
+var reservedNamesPolicyTypeID = uuid.MustParse("db2b9b4c-180d-4529-9701-01541d19f36b")
+
+// the reserved names policy has no settings besides the repositories the policy applies to
+func resourceRepositoryPolicyReservedNames() *schema.Resource {
+	return crud.GenBaseRepositoryPolicyResource(flattenRepositoryPolicyReservedNames, expandRepositoryPolicyReservedNames)
+}
+
+// Convert internal Terraform data structure to an AzDO data structure
+func expandRepositoryPolicyReservedNames(d *schema.ResourceData, clients *config.AggregatedClient) (*policy.PolicyConfiguration, *string, error) {
+	policyConfig, projectID, _ := crud.DoBaseRepositoryPolicyExpansion(d, reservedNamesPolicyTypeID)
+	return policyConfig, projectID, nil
+}
+
+// Convert AzDO data structure to internal Terraform data structure
+func flattenRepositoryPolicyReservedNames(d *schema.ResourceData, clients *config.AggregatedClient, policyConfig *policy.PolicyConfiguration, projectID *string) error {
+	return crud.DoBaseRepositoryPolicyFlattening(d, policyConfig, projectID)
+}
@@ -0,0 +1,154 @@
+// +build all core resource_repository_policy_reserved_names
+
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/config"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var testReservedNamesPolicyProjectID = uuid.New().String()
+
+var testReservedNamesPolicy = policy.PolicyConfiguration{
+	Id:         converter.Int(42),
+	IsEnabled:  converter.Bool(true),
+	IsBlocking: converter.Bool(true),
+	Type: &policy.PolicyTypeRef{
+		Id: &reservedNamesPolicyTypeID,
+	},
+	Settings: map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{
+				"repositoryId": uuid.New().String(),
+			},
+		},
+	},
+}
+
+/**
+ * Begin unit tests
+ */
+
+// verifies that the flatten/expand round trip yields the same policy configuration
+func TestRepositoryPolicyReservedNames_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceRepositoryPolicyReservedNames().Schema, nil)
+	err := flattenRepositoryPolicyReservedNames(resourceData, nil, &testReservedNamesPolicy, &testReservedNamesPolicyProjectID)
+	require.Nil(t, err)
+
+	policyAfterRoundTrip, projectID, err := expandRepositoryPolicyReservedNames(resourceData, nil)
+	require.Nil(t, err)
+	require.Equal(t, testReservedNamesPolicyProjectID, *projectID)
+	require.Equal(t, testReservedNamesPolicy.Id, policyAfterRoundTrip.Id)
+	require.Equal(t, testReservedNamesPolicy.Type, policyAfterRoundTrip.Type)
+
+	expectedSettings, _ := json.Marshal(testReservedNamesPolicy.Settings)
+	actualSettings, _ := json.Marshal(policyAfterRoundTrip.Settings)
+	require.JSONEq(t, string(expectedSettings), string(actualSettings))
+}
+
+// verifies that a policy without repositories applies to all repositories of the project
+func TestRepositoryPolicyReservedNames_Expand_AppliesToAllRepositoriesIfNoneAreConfigured(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, resourceRepositoryPolicyReservedNames().Schema, map[string]interface{}{
+		"project_id": testReservedNamesPolicyProjectID,
+	})
+
+	policyConfig, _, err := expandRepositoryPolicyReservedNames(resourceData, nil)
+	require.Nil(t, err)
+
+	actualSettings, _ := json.Marshal(policyConfig.Settings)
+	require.JSONEq(t, `{"scope": [{"repositoryId": null}]}`, string(actualSettings))
+}
+
+// verifies that a policy for all repositories of the project is flattened without repositories
+func TestRepositoryPolicyReservedNames_Flatten_NoRepositoriesIfAppliedToAllRepositories(t *testing.T) {
+	policyConfig := testReservedNamesPolicy
+	policyConfig.Settings = map[string]interface{}{
+		"scope": []interface{}{
+			map[string]interface{}{"repositoryId": nil},
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, resourceRepositoryPolicyReservedNames().Schema, nil)
+	err := flattenRepositoryPolicyReservedNames(resourceData, nil, &policyConfig, &testReservedNamesPolicyProjectID)
+	require.Nil(t, err)
+	require.Empty(t, resourceData.Get("repository_ids"))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestRepositoryPolicyReservedNames_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := resourceRepositoryPolicyReservedNames()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRepositoryPolicyReservedNames(resourceData, nil, &testReservedNamesPolicy, &testReservedNamesPolicyProjectID)
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &config.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePolicyConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "CreatePolicyConfiguration() Failed")
+}
+
+/**
+ * Begin acceptance tests
+ */
+
+// Verifies that a reserved names policy can be created, updated and imported
+func TestAccRepositoryPolicyReservedNames_CreateAndUpdate(t *testing.T) {
+	projectName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	gitRepoName := testhelper.TestAccResourcePrefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	tfNode := "azuredevops_repository_policy_reserved_names.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testhelper.TestAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccBranchPolicyCheckDestroy("azuredevops_repository_policy_reserved_names"),
+		Steps: []resource.TestStep{
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_reserved_names", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "id"),
+					resource.TestCheckResourceAttr(tfNode, "repository_ids.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "enabled", "true"),
+				),
+			},
+			{
+				Config: testhelper.TestAccRepositoryPolicyResource(projectName, gitRepoName, "azuredevops_repository_policy_reserved_names", "enabled = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testAccBranchPolicyImportStateIDFunc(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	InitProvider()
+}
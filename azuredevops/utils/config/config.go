@@ -12,8 +12,10 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/graph"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/memberentitlementmanagement"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/operations"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/policy"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/serviceendpoint"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/gitext"
 )
 
 // AggregatedClient aggregates all of the underlying clients into a single data
@@ -27,8 +29,10 @@ type AggregatedClient struct {
 	CoreClient                    core.Client
 	BuildClient                   build.Client
 	GitReposClient                git.Client
+	GitReposExtClient             gitext.Client
 	GraphClient                   graph.Client
 	OperationsClient              operations.Client
+	PolicyClient                  policy.Client
 	ServiceEndpointClient         serviceendpoint.Client
 	TaskAgentClient               taskagent.Client
 	MemberEntitleManagementClient memberentitlementmanagement.Client
@@ -92,6 +96,14 @@ func GetAzdoClient(azdoPAT string, organizationURL string) (*AggregatedClient, e
 		return nil, err
 	}
 
+	// client for the git repository attributes that are not part of the 5.1 SDK models (enable/disable repositories...):
+	//	https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/update?view=azure-devops-rest-6.0
+	gitReposExtClient, err := gitext.NewClient(ctx, connection)
+	if err != nil {
+		log.Printf("getAzdoClient(): gitext.NewClient failed.")
+		return nil, err
+	}
+
 	//  https://docs.microsoft.com/en-us/rest/api/azure/devops/graph/?view=azure-devops-rest-5.1
 	graphClient, err := graph.NewClient(ctx, connection)
 	if err != nil {
@@ -99,6 +111,14 @@ func GetAzdoClient(azdoPAT string, organizationURL string) (*AggregatedClient, e
 		return nil, err
 	}
 
+	// client for these APIs (includes CRUD for AzDO branch and repository policies...):
+	//	https://docs.microsoft.com/en-us/rest/api/azure/devops/policy/?view=azure-devops-rest-5.1
+	policyClient, err := policy.NewClient(ctx, connection)
+	if err != nil {
+		log.Printf("getAzdoClient(): policy.NewClient failed.")
+		return nil, err
+	}
+
 	memberentitlementmanagementClient, err := memberentitlementmanagement.NewClient(ctx, connection)
 	if err != nil {
 		log.Printf("getAzdoClient(): memberentitlementmanagement.NewClient failed.")
@@ -109,8 +129,10 @@ func GetAzdoClient(azdoPAT string, organizationURL string) (*AggregatedClient, e
 		CoreClient:                    coreClient,
 		BuildClient:                   buildClient,
 		GitReposClient:                gitReposClient,
+		GitReposExtClient:             gitReposExtClient,
 		GraphClient:                   graphClient,
 		OperationsClient:              operationsClient,
+		PolicyClient:                  policyClient,
 		ServiceEndpointClient:         serviceEndpointClient,
 		TaskAgentClient:               taskagentClient,
 		MemberEntitleManagementClient: memberentitlementmanagementClient,
@@ -26,6 +26,16 @@ func TestInt(t *testing.T) {
 	}
 }
 
+func TestToInt(t *testing.T) {
+	value := 123456
+	if value != ToInt(&value, 0) {
+		t.Errorf("The value returned is different from the referenced value")
+	}
+	if ToInt(nil, 42) != 42 {
+		t.Errorf("The default value is not returned for a nil pointer")
+	}
+}
+
 func TestBoolTrue(t *testing.T) {
 	value := true
 	valuePtr := Bool(value)
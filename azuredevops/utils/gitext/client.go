@@ -0,0 +1,119 @@
+// Package gitext provides access to git repository attributes that were added to the Azure DevOps
+// REST API after version 5.1 and are therefore not part of the models of the git SDK client.
+package gitext
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/git"
+)
+
+// the location of the repositories API, see git.Client.GetRepository
+var repositoriesLocationID, _ = uuid.Parse("225f7195-f9c7-4d14-ab28-a83f7ff77e1f")
+
+// the first API version that supports disabling repositories
+const repositoriesAPIVersion = "6.0"
+
+// Client covers the git repository attributes that are missing from git.Client
+type Client interface {
+	// Retrieve a git repository including the attributes missing from git.GitRepository.
+	GetRepository(context.Context, GetRepositoryArgs) (*GitRepository, error)
+	// Enable or disable a git repository.
+	UpdateRepositoryIsDisabled(context.Context, UpdateRepositoryIsDisabledArgs) (*GitRepository, error)
+}
+
+// ClientImpl is the default implementation of Client
+type ClientImpl struct {
+	Client azuredevops.Client
+}
+
+// NewClient creates a client that uses the same resource area as the git SDK client
+func NewClient(ctx context.Context, connection *azuredevops.Connection) (Client, error) {
+	client, err := connection.GetClientByResourceAreaId(ctx, git.ResourceAreaId)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientImpl{
+		Client: *client,
+	}, nil
+}
+
+// GitRepository extends git.GitRepository with the attributes added after API version 5.1
+type GitRepository struct {
+	git.GitRepository
+	// True if the repository is disabled
+	IsDisabled *bool `json:"isDisabled,omitempty"`
+}
+
+// GetRepository retrieves a git repository
+func (client *ClientImpl) GetRepository(ctx context.Context, args GetRepositoryArgs) (*GitRepository, error) {
+	routeValues := make(map[string]string)
+	if args.Project != nil && *args.Project != "" {
+		routeValues["project"] = *args.Project
+	}
+	if args.RepositoryId == nil || *args.RepositoryId == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.RepositoryId"}
+	}
+	routeValues["repositoryId"] = *args.RepositoryId
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, repositoriesLocationID, repositoriesAPIVersion, routeValues, nil, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue GitRepository
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// GetRepositoryArgs are the arguments for the GetRepository function
+type GetRepositoryArgs struct {
+	// (required) The name or ID of the repository.
+	RepositoryId *string
+	// (optional) Project ID or project name
+	Project *string
+}
+
+// UpdateRepositoryIsDisabled enables or disables a git repository. A disabled repository can neither
+// be read nor written by git clients.
+func (client *ClientImpl) UpdateRepositoryIsDisabled(ctx context.Context, args UpdateRepositoryIsDisabledArgs) (*GitRepository, error) {
+	if args.IsDisabled == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.IsDisabled"}
+	}
+	routeValues := make(map[string]string)
+	if args.Project != nil && *args.Project != "" {
+		routeValues["project"] = *args.Project
+	}
+	if args.RepositoryId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.RepositoryId"}
+	}
+	routeValues["repositoryId"] = (*args.RepositoryId).String()
+
+	body, marshalErr := json.Marshal(map[string]bool{"isDisabled": *args.IsDisabled})
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	resp, err := client.Client.Send(ctx, http.MethodPatch, repositoriesLocationID, repositoriesAPIVersion, routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue GitRepository
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// UpdateRepositoryIsDisabledArgs are the arguments for the UpdateRepositoryIsDisabled function
+type UpdateRepositoryIsDisabledArgs struct {
+	// (required) True to disable the repository, false to enable it
+	IsDisabled *bool
+	// (required) The ID of the repository.
+	RepositoryId *uuid.UUID
+	// (optional) Project ID or project name
+	Project *string
+}
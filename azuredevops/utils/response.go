@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+)
+
+// ResponseWasNotFound was used for check if error is due to resource not found
+func ResponseWasNotFound(err error) bool {
+	return ResponseWasStatusCode(err, http.StatusNotFound)
+}
+
+// ResponseWasStatusCode was used for check if error status code was specific http status code
+func ResponseWasStatusCode(err error, statusCode int) bool {
+	if err == nil {
+		return false
+	}
+
+	// the SDK returns the wrapped error both by value and by reference
+	switch wrappedErr := err.(type) {
+	case azuredevops.WrappedError:
+		return wrappedErr.StatusCode != nil && *wrappedErr.StatusCode == statusCode
+	case *azuredevops.WrappedError:
+		return wrappedErr.StatusCode != nil && *wrappedErr.StatusCode == statusCode
+	}
+	return false
+}
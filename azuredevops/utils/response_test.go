@@ -0,0 +1,24 @@
+// +build all utils response
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseWasNotFound(t *testing.T) {
+	notFound := http.StatusNotFound
+	badRequest := http.StatusBadRequest
+
+	require.True(t, ResponseWasNotFound(azuredevops.WrappedError{StatusCode: &notFound}))
+	require.True(t, ResponseWasNotFound(&azuredevops.WrappedError{StatusCode: &notFound}))
+	require.False(t, ResponseWasNotFound(&azuredevops.WrappedError{StatusCode: &badRequest}))
+	require.False(t, ResponseWasNotFound(&azuredevops.WrappedError{}))
+	require.False(t, ResponseWasNotFound(errors.New("not a REST error")))
+	require.False(t, ResponseWasNotFound(nil))
+}
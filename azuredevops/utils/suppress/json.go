@@ -0,0 +1,21 @@
+package suppress
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// JSONDifference reports whether old and new are semantically equal JSON documents,
+// ignoring differences in whitespace and in the order of object keys.
+func JSONDifference(_, old, new string, _ *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
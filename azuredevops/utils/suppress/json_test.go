@@ -0,0 +1,65 @@
+// +build all utils json
+
+package suppress
+
+import "testing"
+
+func TestJSONDifference(t *testing.T) {
+	cases := []struct {
+		Name     string
+		StringA  string
+		StringB  string
+		Suppress bool
+	}{
+		{
+			Name:     "empty",
+			StringA:  "",
+			StringB:  "",
+			Suppress: false,
+		},
+		{
+			Name:     "empty vs document",
+			StringA:  `{"a": 1}`,
+			StringB:  "",
+			Suppress: false,
+		},
+		{
+			Name:     "different values",
+			StringA:  `{"a": 1}`,
+			StringB:  `{"a": 2}`,
+			Suppress: false,
+		},
+		{
+			Name:     "same document",
+			StringA:  `{"a": 1, "b": [true, "c"]}`,
+			StringB:  `{"a": 1, "b": [true, "c"]}`,
+			Suppress: true,
+		},
+		{
+			Name:     "same document different whitespace",
+			StringA:  `{"a":1,"b":[true,"c"]}`,
+			StringB:  "{\n  \"a\": 1,\n  \"b\": [true, \"c\"]\n}",
+			Suppress: true,
+		},
+		{
+			Name:     "same document different key order",
+			StringA:  `{"a": 1, "b": {"c": null, "d": 2}}`,
+			StringB:  `{"b": {"d": 2, "c": null}, "a": 1}`,
+			Suppress: true,
+		},
+		{
+			Name:     "different array order",
+			StringA:  `{"a": [1, 2]}`,
+			StringB:  `{"a": [2, 1]}`,
+			Suppress: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if JSONDifference("test", tc.StringA, tc.StringB, nil) != tc.Suppress {
+				t.Fatalf("Expected JSONDifference to return %t for '%q' == '%q'", tc.Suppress, tc.StringA, tc.StringB)
+			}
+		})
+	}
+}
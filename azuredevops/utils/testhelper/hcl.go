@@ -20,6 +20,34 @@ resource "azuredevops_azure_git_repository" "gitrepo" {
 	return fmt.Sprintf("%s\n%s", projectResource, azureGitRepoResource)
 }
 
+// TestAccGitRepositoryBranchResource HCL describing a branch created from the default branch of an AzDO GIT repository
+func TestAccGitRepositoryBranchResource(projectName string, gitRepoName string, branchName string, locked bool) string {
+	branchResource := fmt.Sprintf(`
+resource "azuredevops_git_repository_branch" "branch" {
+	repository_id = azuredevops_azure_git_repository.gitrepo.id
+	name          = "%s"
+	ref_branch    = "master"
+	locked        = %t
+}`, branchName, locked)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, branchResource)
+}
+
+// TestAccGitRepositoryTagResource HCL describing a tag on the default branch of an AzDO GIT repository
+func TestAccGitRepositoryTagResource(projectName string, gitRepoName string, tagName string, message string) string {
+	tagResource := fmt.Sprintf(`
+resource "azuredevops_git_repository_tag" "tag" {
+	repository_id = azuredevops_azure_git_repository.gitrepo.id
+	name          = "%s"
+	ref_branch    = "master"
+	message       = "%s"
+}`, tagName, message)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, tagResource)
+}
+
 // TestAccGroupDataSource HCL describing an AzDO Group Data Source
 func TestAccGroupDataSource(projectName string, groupName string) string {
 	dataSource := fmt.Sprintf(`
@@ -32,6 +60,30 @@ data "azuredevops_group" "group" {
 	return fmt.Sprintf("%s\n%s", projectResource, dataSource)
 }
 
+// TestAccGitRepositoriesDataSource HCL describing a data source listing the AzDO GIT repositories of a project
+func TestAccGitRepositoriesDataSource(projectName string, gitRepoName string) string {
+	dataSource := `
+data "azuredevops_git_repositories" "repositories" {
+	project_id = azuredevops_project.project.id
+	name       = azuredevops_azure_git_repository.gitrepo.name
+}`
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, dataSource)
+}
+
+// TestAccGitRepositoryRefsDataSource HCL describing a data source listing the branches of an AzDO GIT repository
+func TestAccGitRepositoryRefsDataSource(projectName string, gitRepoName string) string {
+	dataSource := `
+data "azuredevops_git_repository_refs" "refs" {
+	repository_id = azuredevops_azure_git_repository.gitrepo.id
+	filter        = "refs/heads/"
+}`
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, dataSource)
+}
+
 // TestAccProjectResource HCL describing an AzDO project
 func TestAccProjectResource(projectName string) string {
 	return fmt.Sprintf(`
@@ -137,6 +189,84 @@ resource "azuredevops_build_definition" "build" {
 	return fmt.Sprintf("%s\n%s", projectResource, buildDefinitionResource)
 }
 
+// TestAccBuildDefinitionResourceWithSettings HCL describing an AzDO build definition with the given additional settings,
+// e.g. trigger blocks
+func TestAccBuildDefinitionResourceWithSettings(projectName string, buildDefinitionName string, settings string) string {
+	buildDefinitionResource := fmt.Sprintf(`
+resource "azuredevops_build_definition" "build" {
+	project_id      = azuredevops_project.project.id
+	name            = "%s"
+	agent_pool_name = "Hosted Ubuntu 1604"
+
+	repository {
+	  repo_type             = "GitHub"
+	  repo_name             = "repoOrg/repoName"
+	  branch_name           = "branch"
+	  yml_path              = "path/to/yaml"
+	}
+
+	%s
+}`, buildDefinitionName, settings)
+
+	projectResource := TestAccProjectResource(projectName)
+	return fmt.Sprintf("%s\n%s", projectResource, buildDefinitionResource)
+}
+
+// TestAccBuildDefinitionResourceWithCompletionTrigger HCL describing an AzDO build definition triggered by the
+// completion of the builds of an upstream build definition
+func TestAccBuildDefinitionResourceWithCompletionTrigger(projectName string, upstreamName string, buildDefinitionName string) string {
+	upstreamResource := fmt.Sprintf(`
+resource "azuredevops_build_definition" "upstream" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+
+	repository {
+	  repo_type   = "GitHub"
+	  repo_name   = "repoOrg/repoName"
+	  branch_name = "branch"
+	  yml_path    = "path/to/yaml"
+	}
+}`, upstreamName)
+
+	buildDefinitionResource := TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, `
+	build_completion_trigger {
+		definition_id = azuredevops_build_definition.upstream.id
+		branch_filter {
+			include = ["master"]
+		}
+	}`)
+	return fmt.Sprintf("%s\n%s", buildDefinitionResource, upstreamResource)
+}
+
+// TestAccBuildFolderResource HCL describing an AzDO build folder
+func TestAccBuildFolderResource(projectName string, path string, description string) string {
+	buildFolderResource := fmt.Sprintf(`
+resource "azuredevops_build_folder" "folder" {
+	project_id  = azuredevops_project.project.id
+	path        = "%s"
+	description = "%s"
+}`, strings.ReplaceAll(path, `\`, `\\`), description)
+
+	projectResource := TestAccProjectResource(projectName)
+	return fmt.Sprintf("%s\n%s", projectResource, buildFolderResource)
+}
+
+// TestAccBuildRetentionSettingsResource HCL describing the build retention settings of an AzDO project
+func TestAccBuildRetentionSettingsResource(projectName string, daysToKeep int, minimumToKeep int) string {
+	retentionSettingsResource := fmt.Sprintf(`
+resource "azuredevops_build_retention_settings" "retention" {
+	project_id = azuredevops_project.project.id
+
+	default_retention_policy {
+		days_to_keep    = %d
+		minimum_to_keep = %d
+	}
+}`, daysToKeep, minimumToKeep)
+
+	projectResource := TestAccProjectResource(projectName)
+	return fmt.Sprintf("%s\n%s", projectResource, retentionSettingsResource)
+}
+
 // TestAccGroupMembershipResource full terraform stanza to standup a group membership
 func TestAccGroupMembershipResource(projectName, groupName, userPrincipalName string) string {
 	membershipDependenciesStanza := TestAccGroupMembershipDependencies(projectName, groupName, userPrincipalName)
@@ -172,3 +302,296 @@ output "user_descriptor" {
 }
 `, projectName, groupName, userPrincipalName)
 }
+
+// TestAccBranchPolicyMinReviewersResource HCL describing a minimum reviewer policy on the default branch of an AzDO GIT repository
+func TestAccBranchPolicyMinReviewersResource(projectName string, gitRepoName string, reviewerCount int, enabled bool) string {
+	policyResource := fmt.Sprintf(`
+resource "azuredevops_branch_policy_min_reviewers" "policy" {
+	project_id = azuredevops_project.project.id
+	enabled    = %t
+	blocking   = true
+
+	settings {
+		reviewer_count       = %d
+		submitter_can_vote   = false
+		reset_on_source_push = true
+
+		scope {
+			repository_id  = azuredevops_azure_git_repository.gitrepo.id
+			repository_ref = azuredevops_azure_git_repository.gitrepo.default_branch
+			match_type     = "Exact"
+		}
+	}
+}`, enabled, reviewerCount)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, policyResource)
+}
+
+// TestAccBranchPolicyBuildValidationResource HCL describing a build validation policy on the default branch of an AzDO GIT repository
+func TestAccBranchPolicyBuildValidationResource(projectName string, gitRepoName string, displayName string, validDuration int) string {
+	buildDefinitionResource := fmt.Sprintf(`
+resource "azuredevops_build_definition" "build" {
+	project_id      = azuredevops_project.project.id
+	name            = "%s"
+	agent_pool_name = "Hosted Ubuntu 1604"
+
+	repository {
+		repo_type   = "TfsGit"
+		repo_name   = azuredevops_azure_git_repository.gitrepo.name
+		branch_name = azuredevops_azure_git_repository.gitrepo.default_branch
+		yml_path    = "azure-pipelines.yml"
+	}
+}`, gitRepoName)
+
+	policyResource := fmt.Sprintf(`
+resource "azuredevops_branch_policy_build_validation" "policy" {
+	project_id = azuredevops_project.project.id
+
+	settings {
+		build_definition_id = azuredevops_build_definition.build.id
+		display_name        = "%s"
+		valid_duration      = %d
+		filename_patterns   = ["/src/*", "!/src/*.md"]
+
+		scope {
+			repository_id  = azuredevops_azure_git_repository.gitrepo.id
+			repository_ref = azuredevops_azure_git_repository.gitrepo.default_branch
+			match_type     = "Exact"
+		}
+	}
+}`, displayName, validDuration)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s\n%s", gitRepoResource, buildDefinitionResource, policyResource)
+}
+
+// TestAccBranchPolicyAutoReviewersResource HCL describing a policy that adds a project group as required reviewer on the default branch of an AzDO GIT repository
+func TestAccBranchPolicyAutoReviewersResource(projectName string, gitRepoName string, groupName string, message string) string {
+	groupDataSource := fmt.Sprintf(`
+data "azuredevops_group" "group" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+}`, groupName)
+
+	policyResource := fmt.Sprintf(`
+resource "azuredevops_branch_policy_auto_reviewers" "policy" {
+	project_id = azuredevops_project.project.id
+
+	settings {
+		reviewer_descriptors = [data.azuredevops_group.group.descriptor]
+		message              = "%s"
+		filename_patterns    = ["/src/*"]
+
+		scope {
+			repository_id  = azuredevops_azure_git_repository.gitrepo.id
+			repository_ref = azuredevops_azure_git_repository.gitrepo.default_branch
+			match_type     = "Exact"
+		}
+	}
+}`, message)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s\n%s", gitRepoResource, groupDataSource, policyResource)
+}
+
+// TestAccBranchPolicyResource HCL describing a branch policy of the given type on the default branch of an AzDO GIT repository.
+// The given settings are added to the settings block of the policy.
+func TestAccBranchPolicyResource(projectName string, gitRepoName string, resourceType string, enabled bool, settings string) string {
+	policyResource := fmt.Sprintf(`
+resource "%s" "policy" {
+	project_id = azuredevops_project.project.id
+	enabled    = %t
+	blocking   = true
+
+	settings {
+		%s
+
+		scope {
+			repository_id  = azuredevops_azure_git_repository.gitrepo.id
+			repository_ref = azuredevops_azure_git_repository.gitrepo.default_branch
+			match_type     = "Exact"
+		}
+	}
+}`, resourceType, enabled, settings)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, policyResource)
+}
+
+// TestAccRepositoryPolicyResource HCL describing a repository policy of the given type on an AzDO GIT repository.
+// The given settings are added to the policy.
+func TestAccRepositoryPolicyResource(projectName string, gitRepoName string, resourceType string, settings string) string {
+	policyResource := fmt.Sprintf(`
+resource "%s" "policy" {
+	project_id     = azuredevops_project.project.id
+	repository_ids = [azuredevops_azure_git_repository.gitrepo.id]
+	%s
+}`, resourceType, settings)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, policyResource)
+}
+
+// TestAccPolicyConfigurationResource HCL describing a generic minimum reviewers policy on the master branch of an AzDO GIT repository
+func TestAccPolicyConfigurationResource(projectName string, gitRepoName string, reviewerCount int) string {
+	policyResource := fmt.Sprintf(`
+resource "azuredevops_policy_configuration" "policy" {
+	project_id = azuredevops_project.project.id
+	type_id    = "fa4e907d-c16b-4a4c-9dfa-4906e5d171dd"
+	settings   = jsonencode({
+		scope = [{
+			repositoryId = azuredevops_azure_git_repository.gitrepo.id
+			refName      = "refs/heads/master"
+			matchKind    = "Exact"
+		}]
+		minimumApproverCount = %d
+		creatorVoteCounts    = false
+	})
+}`, reviewerCount)
+
+	gitRepoResource := TestAccAzureGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf("%s\n%s", gitRepoResource, policyResource)
+}
+
+// TestAccPolicyTypesDataSource HCL describing a data source listing the policy types of an AzDO project
+func TestAccPolicyTypesDataSource(projectName string) string {
+	dataSource := `
+data "azuredevops_policy_types" "types" {
+	project_id = azuredevops_project.project.id
+}`
+
+	projectResource := TestAccProjectResource(projectName)
+	return fmt.Sprintf("%s\n%s", projectResource, dataSource)
+}
+
+// TestAccBuildDefinitionDataSource HCL describing a data source looking up an AzDO build definition by its name
+func TestAccBuildDefinitionDataSource(projectName string, buildDefinitionName string) string {
+	dataSource := `
+data "azuredevops_build_definition" "build" {
+	project_id = azuredevops_project.project.id
+	name       = azuredevops_build_definition.build.name
+}`
+
+	buildDefinitionResource := TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, "")
+	return fmt.Sprintf("%s\n%s", buildDefinitionResource, dataSource)
+}
+
+// TestAccBuildsDataSource HCL describing a data source listing the builds of an AzDO build definition
+func TestAccBuildsDataSource(projectName string, buildDefinitionName string) string {
+	dataSource := `
+data "azuredevops_builds" "builds" {
+	project_id    = azuredevops_project.project.id
+	definition_id = azuredevops_build_definition.build.id
+	status        = "completed"
+}`
+
+	buildDefinitionResource := TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, "")
+	return fmt.Sprintf("%s\n%s", buildDefinitionResource, dataSource)
+}
+
+// TestAccBuildRunResource HCL describing a build queued for an AzDO build definition
+func TestAccBuildRunResource(projectName string, buildDefinitionName string, trigger string) string {
+	buildRunResource := fmt.Sprintf(`
+resource "azuredevops_build_run" "run" {
+	project_id    = azuredevops_project.project.id
+	definition_id = azuredevops_build_definition.build.id
+
+	variables = {
+		"system.debug" = "true"
+	}
+
+	triggers = {
+		trigger = "%s"
+	}
+}`, trigger)
+
+	buildDefinitionResource := TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, "")
+	return fmt.Sprintf("%s\n%s", buildDefinitionResource, buildRunResource)
+}
+
+// TestAccBuildDefinitionResourceTfvc HCL describing an AzDO build definition of the TFVC repository of a project
+func TestAccBuildDefinitionResourceTfvc(projectName string, buildDefinitionName string) string {
+	buildDefinitionResource := fmt.Sprintf(`
+resource "azuredevops_build_definition" "build" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+
+	repository {
+	  repo_type   = "TfsVersionControl"
+	  repo_name   = azuredevops_project.project.project_name
+	  branch_name = "$/${azuredevops_project.project.project_name}"
+
+	  workspace_mapping {
+	    server_path = "$/${azuredevops_project.project.project_name}"
+	  }
+	}
+
+	ci_trigger {
+	  override {
+	    path_filter {
+	      include = ["$/${azuredevops_project.project.project_name}"]
+	    }
+	  }
+	}
+
+	gated_checkin_trigger {
+	  run_continuous_integration = true
+	}
+}`, buildDefinitionName)
+
+	projectResource := fmt.Sprintf(`
+resource "azuredevops_project" "project" {
+	project_name       = "%s"
+	description        = "%s-description"
+	visibility         = "private"
+	version_control    = "Tfvc"
+	work_item_template = "Agile"
+}`, projectName, projectName)
+	return fmt.Sprintf("%s\n%s", projectResource, buildDefinitionResource)
+}
+
+// TestAccBuildDefinitionResourceCloned HCL describing an AzDO build definition cloned from a template build definition
+func TestAccBuildDefinitionResourceCloned(projectName string, templateName string, buildDefinitionName string) string {
+	templateResource := fmt.Sprintf(`
+resource "azuredevops_build_definition" "template" {
+	project_id = azuredevops_project.project.id
+	name       = "%s"
+
+	repository {
+	  repo_type   = "GitHub"
+	  repo_name   = "repoOrg/repoName"
+	  branch_name = "branch"
+	  yml_path    = "path/to/yaml"
+	}
+
+	variable {
+	  name  = "TemplateVariable"
+	  value = "template"
+	}
+
+	ci_trigger {
+	  use_yaml = true
+	}
+
+	schedule {
+	  days_to_build = ["Monday"]
+	  start_hours   = 2
+	  branch_filter {
+	    include = ["master"]
+	  }
+	}
+}`, templateName)
+
+	buildDefinitionResource := TestAccBuildDefinitionResourceWithSettings(projectName, buildDefinitionName, `
+	source_definition_id = azuredevops_build_definition.template.id
+
+	ci_trigger {
+		override {
+			branch_filter {
+				include = ["master"]
+			}
+		}
+	}`)
+	return fmt.Sprintf("%s\n%s", buildDefinitionResource, templateResource)
+}
@@ -0,0 +1,41 @@
+package validate
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// URL parses an absolute http or https URL, returning warnings and errors.
+func URL(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	u, err := url.Parse(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q isn't a valid URL (%q): %+v", k, v, err))
+		return
+	}
+	if u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		errors = append(errors, fmt.Errorf("%q isn't a valid URL (%q): expected an absolute http or https URL", k, v))
+	}
+
+	return warnings, errors
+}
+
+// URLOrEmpty parses an absolute http or https URL, returning nil for warnings if i is empty.
+func URLOrEmpty(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if v == "" {
+		return
+	}
+
+	return URL(i, k)
+}
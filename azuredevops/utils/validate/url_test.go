@@ -0,0 +1,56 @@
+// +build all utils url
+
+package validate
+
+import "testing"
+
+func TestURL(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Errors int
+	}{
+		{
+			Input:  "",
+			Errors: 1,
+		},
+		{
+			Input:  "github.contoso.com/org/repo.git",
+			Errors: 1,
+		},
+		{
+			Input:  "ssh://git@github.contoso.com/org/repo.git",
+			Errors: 1,
+		},
+		{
+			Input:  "https://",
+			Errors: 1,
+		},
+		{
+			Input:  "https://github.contoso.com/org/repo.git",
+			Errors: 0,
+		},
+		{
+			Input:  "http://git.contoso.com:8080/repo",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			_, errors := URL(tc.Input, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected URL to have %d not %d errors for %q", tc.Errors, len(errors), tc.Input)
+			}
+		})
+	}
+}
+
+func TestURLOrEmpty(t *testing.T) {
+	if _, errors := URLOrEmpty("", "test"); len(errors) != 0 {
+		t.Fatalf("Expected URLOrEmpty to have no errors for an empty string")
+	}
+	if _, errors := URLOrEmpty("not a url", "test"); len(errors) != 1 {
+		t.Fatalf("Expected URLOrEmpty to have 1 error for an invalid URL")
+	}
+}
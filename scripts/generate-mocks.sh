@@ -71,6 +71,9 @@ function generate_mock_clients() {
         #       leaving the parameter hard-coded here.
         generate_single_mock_client "$PACKAGE" "Client" || true
     done
+
+    # clients of this provider that complement the Azure DevOps Go SDK
+    generate_single_mock_client "github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/gitext" "Client" || true
 }
 
 function generate_mocks() {